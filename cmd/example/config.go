@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 
+	"github.com/spf13/viper"
 	httpserver "github.com/yolkhovyy/go-otelw/cmd/example/internal/server/http"
 	"github.com/yolkhovyy/go-otelw/otelw"
 	"github.com/yolkhovyy/go-utilities/viperx"
@@ -20,7 +21,7 @@ func (c *Config) Load(configFile string, prefix string) error {
 	vprx.SetDefaults(otelw.Defaults())
 	vprx.SetDefaults(httpserver.Defaults())
 
-	if err := vprx.Load(c); err != nil {
+	if err := vprx.Load(c, viper.DecodeHook(otelw.DecodeHook())); err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
@@ -108,7 +108,7 @@ func worker(
 
 	if sequence > workThreshold {
 		err = fmt.Errorf("%s: %w", msg, ErrTimeout)
-		logger.ErrorContext(ctx, msg, append(logAttrs, slog.String("error", err.Error()))...)
+		logger.ErrorContext(ctx, msg, append(logAttrs, slogw.Err(err))...)
 		span.AddEvent(msg, trace.WithAttributes(append(eventAttrs, attribute.String("error", err.Error()))...))
 
 		errChan <- err
@@ -18,6 +18,10 @@ func Configure(
 	attrs []attribute.KeyValue,
 	writers ...io.Writer,
 ) (*slogw.Logger, *tracew.Tracer, *metricw.Metric, error) {
+	if err := config.Validate(); err != nil {
+		return nil, nil, nil, fmt.Errorf("otelw validate: %w", err)
+	}
+
 	logger, err := slogw.Configure(ctx, config.Logger, attrs, writers...)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("slogw configure: %w", err)
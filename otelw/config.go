@@ -4,6 +4,7 @@ import (
 	"github.com/yolkhovyy/go-otelw/otelw/metricw"
 	"github.com/yolkhovyy/go-otelw/otelw/slogw"
 	"github.com/yolkhovyy/go-otelw/otelw/tracew"
+	"github.com/yolkhovyy/go-otelw/otelw/validation"
 )
 
 // Config defines the configuration structure for the OpenTelemetry wrapper.
@@ -41,3 +42,14 @@ func Defaults() map[string]any {
 
 	return defaults
 }
+
+// Validate checks the logger, tracer and metric configurations and returns
+// all problems found, qualified with the configuration field paths,
+// e.g. "Tracer.OTLP.Endpoint: empty endpoint", or nil if there are none.
+func (c Config) Validate() error {
+	return validation.Join("",
+		validation.Join("Logger", c.Logger.Validate()),
+		validation.Join("Tracer", c.Tracer.Validate()),
+		validation.Join("Metric", c.Metric.Validate()),
+	)
+}
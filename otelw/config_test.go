@@ -1,9 +1,12 @@
 package otelw
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/yolkhovyy/go-otelw/otelw/metricw"
@@ -40,30 +43,59 @@ func TestBaseLoad(t *testing.T) {
 				err: false,
 				config: Config{
 					Logger: slogw.Config{
-						Caller:     true,
-						Format:     slogw.JSON,
-						Level:      "trace",
-						TimeFormat: time.RFC3339Nano,
+						Caller:      true,
+						Format:      slogw.JSON,
+						Level:       "trace",
+						TimeFormat:  time.RFC3339Nano,
+						BaggageKeys: []string{"tenant.id"},
 						OTLP: otlp.Config{
 							Protocol: otlp.GRPC,
 							Endpoint: "foo:4242",
 						},
 					},
 					Tracer: tracew.Config{
-						Enable: true,
+						Enable:      true,
+						IDGenerator: tracew.IDGeneratorXRay,
+						EnvParent:   true,
+						BaggageKeys: []string{"tenant.id", "request.origin"},
 						OTLP: otlp.Config{
 							Protocol: otlp.GRPC,
 							Endpoint: "foo:4242",
 						},
 					},
 					Metric: metricw.Config{
-						Enable:     true,
-						Prometheus: true,
-						Interval:   42 * time.Second,
+						Enable:      true,
+						Prometheus:  true,
+						Runtime:     true,
+						Host:        true,
+						Interval:    42 * time.Second,
+						Temporality: metricw.TemporalityDelta,
+						TemporalityOverrides: map[string]metricw.Temporality{
+							metricw.InstrumentHistogram: metricw.TemporalityCumulative,
+						},
+						CardinalityLimit: 2000,
+						ExemplarFilter:   metricw.ExemplarAlwaysOn,
 						OTLP: otlp.Config{
 							Protocol: otlp.GRPC,
 							Endpoint: "foo:4242",
 						},
+						Scrape: metricw.ScrapeConfig{
+							Enable:  true,
+							Address: ":4243",
+							Path:    "/prometheus",
+						},
+						Views: []metricw.View{
+							{
+								Instrument:    "http.server.duration",
+								Name:          "http.server.latency",
+								AttributeKeys: []string{"http.method", "http.route"},
+								Buckets:       []float64{0.01, 0.1, 1},
+							},
+							{
+								Instrument:  "rpc.*",
+								Aggregation: metricw.AggregationDrop,
+							},
+						},
 					},
 				},
 			},
@@ -87,24 +119,86 @@ func TestBaseLoad(t *testing.T) {
 						},
 					},
 					Tracer: tracew.Config{
-						Enable: tracew.DefaultEnable,
+						Enable:      tracew.DefaultEnable,
+						IDGenerator: tracew.DefaultIDGenerator,
 						OTLP: otlp.Config{
 							Protocol: otlp.DefaultProtocol,
 							Endpoint: otlp.DefaultEndpoint,
 						},
 					},
 					Metric: metricw.Config{
-						Enable:     metricw.DefaultEnable,
-						Prometheus: metricw.DefaultPrometheus,
-						Interval:   metricw.DefaultInterval,
+						Enable:         metricw.DefaultEnable,
+						Prometheus:     metricw.DefaultPrometheus,
+						Interval:       metricw.DefaultInterval,
+						Temporality:    metricw.DefaultTemporality,
+						ExemplarFilter: metricw.DefaultExemplarFilter,
 						OTLP: otlp.Config{
 							Protocol: otlp.DefaultProtocol,
 							Endpoint: otlp.DefaultEndpoint,
 						},
+						Scrape: metricw.ScrapeConfig{
+							Enable:  metricw.DefaultScrapeEnable,
+							Address: metricw.DefaultScrapeAddress,
+							Path:    metricw.DefaultScrapePath,
+						},
 					},
 				},
 			},
 		},
+		{
+			name: "aliases",
+			args: args{
+				configFile: "test_data/aliases_config.yml",
+			},
+			want: want{
+				err: false,
+				config: Config{
+					Logger: slogw.Config{
+						Caller:     slogw.DefaultCaller,
+						Format:     slogw.Console,
+						Level:      slogw.DefaultLevel,
+						TimeFormat: slogw.DefaultTimeFormat,
+						OTLP: otlp.Config{
+							Protocol: otlp.HTTP,
+							Endpoint: "foo:4242",
+						},
+					},
+					Tracer: tracew.Config{
+						Enable:      tracew.DefaultEnable,
+						IDGenerator: tracew.IDGeneratorXRay,
+						OTLP: otlp.Config{
+							Protocol: otlp.GRPC,
+							Endpoint: "foo:4242",
+						},
+					},
+					Metric: metricw.Config{
+						Enable:         metricw.DefaultEnable,
+						Prometheus:     metricw.DefaultPrometheus,
+						Interval:       metricw.DefaultInterval,
+						Temporality:    metricw.TemporalityLowMemory,
+						ExemplarFilter: metricw.DefaultExemplarFilter,
+						OTLP: otlp.Config{
+							Protocol: otlp.HTTP,
+							Endpoint: "foo:4242",
+						},
+						Scrape: metricw.ScrapeConfig{
+							Enable:  metricw.DefaultScrapeEnable,
+							Address: metricw.DefaultScrapeAddress,
+							Path:    metricw.DefaultScrapePath,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid protocol",
+			args: args{
+				configFile: "test_data/invalid_protocol_config.yml",
+			},
+			want: want{
+				err: true,
+			},
+		},
 		{
 			name: "invalid",
 			args: args{
@@ -133,7 +227,7 @@ func TestBaseLoad(t *testing.T) {
 			vprx.SetDefaults(Defaults())
 
 			config := Config{}
-			err := vprx.Load(&config)
+			err := vprx.Load(&config, viper.DecodeHook(DecodeHook()))
 
 			if test.want.err {
 				require.Error(t, err)
@@ -144,3 +238,201 @@ func TestBaseLoad(t *testing.T) {
 		})
 	}
 }
+
+//nolint:funlen
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		config Config
+	}
+
+	type want struct {
+		errs []string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "valid",
+			args: args{
+				config: Config{
+					Logger: slogw.Config{
+						Enable: true,
+						Format: slogw.JSON,
+						Level:  "trace",
+						OTLP: otlp.Config{
+							Protocol: otlp.GRPC,
+							Endpoint: "foo:4242",
+							Insecure: true,
+						},
+					},
+					Tracer: tracew.Config{
+						Enable: false,
+					},
+					Metric: metricw.Config{
+						Enable:   true,
+						Interval: time.Second,
+						OTLP: otlp.Config{
+							Protocol: otlp.HTTP,
+							Endpoint: "foo:4242",
+							Insecure: true,
+						},
+					},
+				},
+			},
+			want: want{},
+		},
+		{
+			name: "invalid",
+			args: args{
+				config: Config{
+					Logger: slogw.Config{
+						Enable:      true,
+						Format:      slogw.Pretty,
+						Level:       "verbose",
+						Fields:      []string{slogw.FieldTime, "foo"},
+						BaggageKeys: []string{"tenant id"},
+					},
+					Tracer: tracew.Config{
+						Enable:      true,
+						IDGenerator: "zipkin",
+						BaggageKeys: []string{"tenant.id", ""},
+						OTLP: otlp.Config{
+							Protocol:    otlp.GRPC,
+							Certificate: "test_data/non-existing.crt",
+						},
+					},
+					Metric: metricw.Config{
+						Enable: true,
+						OTLP: otlp.Config{
+							Protocol: "grpc+http",
+							Endpoint: "foo:4242",
+							Insecure: true,
+						},
+						Temporality: "sideways",
+						TemporalityOverrides: map[string]metricw.Temporality{
+							"timer": metricw.TemporalityDelta,
+						},
+						Views: []metricw.View{
+							{Instrument: "http.*", Name: "http"},
+						},
+					},
+				},
+			},
+			want: want{
+				errs: []string{
+					"Logger.Level: parse level: invalid level verbose",
+					"Logger.Fields[1]: invalid field foo",
+					"Logger.BaggageKeys[0]: invalid baggage key \"tenant id\"",
+					"Tracer.IDGenerator: parse id generator: invalid id generator zipkin",
+					"Tracer.BaggageKeys[1]: invalid baggage key \"\"",
+					"Tracer.OTLP.Endpoint: empty endpoint",
+					"Tracer.OTLP.ClientCertificate: missing file",
+					"Tracer.OTLP.ClientKey: missing file",
+					"Tracer.OTLP.Certificate: tls file: stat test_data/non-existing.crt: no such file or directory",
+					"Metric.Views[0].Name: invalid view: rename of wildcard instrument http.*",
+					"Metric.Interval: invalid interval 0s",
+					"Metric.OTLP.Protocol: parse protocol: invalid protocol grpc+http",
+					"Metric.Temporality: parse temporality: invalid temporality sideways",
+					"Metric.TemporalityOverrides.timer: invalid instrument kind timer",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := test.args.config.Validate()
+
+			if len(test.want.errs) == 0 {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, test.want.errs, strings.Split(err.Error(), "\n"))
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		data string
+	}
+
+	type want struct {
+		err      error
+		format   slogw.Format
+		protocol otlp.Protocol
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "valid",
+			args: args{
+				data: `{"logger": {"format": "json", "otlp": {"protocol": "grpc"}}}`,
+			},
+			want: want{
+				format:   slogw.JSON,
+				protocol: otlp.GRPC,
+			},
+		},
+		{
+			name: "aliases",
+			args: args{
+				data: `{"logger": {"format": " Text ", "otlp": {"protocol": "HTTP"}}}`,
+			},
+			want: want{
+				format:   slogw.Console,
+				protocol: otlp.HTTP,
+			},
+		},
+		{
+			name: "invalid format",
+			args: args{
+				data: `{"logger": {"format": "xml"}}`,
+			},
+			want: want{
+				err: slogw.ErrInvalidFormat,
+			},
+		},
+		{
+			name: "invalid protocol",
+			args: args{
+				data: `{"logger": {"otlp": {"protocol": "grpc+http"}}}`,
+			},
+			want: want{
+				err: otlp.ErrInvalidProtocol,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var config Config
+
+			err := json.Unmarshal([]byte(test.args.data), &config)
+
+			if test.want.err != nil {
+				require.ErrorIs(t, err, test.want.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.want.format, config.Logger.Format)
+				assert.Equal(t, test.want.protocol, config.Logger.OTLP.Protocol)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package otelw
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// DecodeHook returns a mapstructure decode hook which decodes string values,
+// e.g. from environment variables, into types implementing encoding.TextUnmarshaler,
+// such as slogw.Format and otlp.Protocol, so that they are normalized and validated.
+// It also includes the viper default hooks for durations and comma separated slices.
+//
+// Usage:
+//
+//	err := vprx.Load(&config, viper.DecodeHook(otelw.DecodeHook()))
+func DecodeHook() mapstructure.DecodeHookFunc {
+	return mapstructure.ComposeDecodeHookFunc(
+		textUnmarshalerHook,
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+	)
+}
+
+// textUnmarshalerHook unmarshals values of string kind into encoding.TextUnmarshaler targets.
+func textUnmarshalerHook(from reflect.Type, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String {
+		return data, nil
+	}
+
+	result := reflect.New(to)
+
+	unmarshaler, ok := result.Interface().(encoding.TextUnmarshaler)
+	if !ok {
+		return data, nil
+	}
+
+	if err := unmarshaler.UnmarshalText([]byte(reflect.ValueOf(data).String())); err != nil {
+		return nil, fmt.Errorf("decode hook: %w", err)
+	}
+
+	return result.Elem().Interface(), nil
+}
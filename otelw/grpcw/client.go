@@ -0,0 +1,202 @@
+package grpcw
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClient returns the unary client interceptor, see Interceptors.
+func (i *Interceptors) UnaryClient() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		conn *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		options ...grpc.CallOption,
+	) error {
+		if i.skip(method) {
+			return invoker(ctx, method, req, reply, conn, options...)
+		}
+
+		ctx, call := i.startClient(ctx, method, conn.Target())
+
+		call.request(ctx, req)
+
+		err := invoker(ctx, method, req, reply, conn, options...)
+		if err == nil {
+			call.response(ctx, reply)
+		}
+
+		i.finishClient(ctx, call, err)
+
+		return err
+	}
+}
+
+// StreamClient returns the stream client interceptor, see Interceptors.
+// The span of a stream ends when the stream receives its last response, fails,
+// or its context is done, e.g. canceled without reading the stream to its end.
+func (i *Interceptors) StreamClient() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		conn *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		options ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		if i.skip(method) {
+			return streamer(ctx, desc, conn, method, options...)
+		}
+
+		ctx, call := i.startClient(ctx, method, conn.Target())
+
+		stream, err := streamer(ctx, desc, conn, method, options...)
+		if err != nil {
+			i.finishClient(ctx, call, err)
+
+			return nil, err
+		}
+
+		wrapped := &clientStream{
+			ClientStream:  stream,
+			ctx:           ctx,
+			call:          call,
+			interceptors:  i,
+			serverStreams: desc.ServerStreams,
+			done:          make(chan struct{}),
+		}
+
+		go wrapped.finishOnDone()
+
+		return wrapped, nil
+	}
+}
+
+// startClient starts the client span of a call and injects its context into the outgoing metadata.
+func (i *Interceptors) startClient(ctx context.Context, fullMethod, target string) (context.Context, *call) {
+	attrs := append(methodAttributes(fullMethod), targetAttributes(target)...)
+
+	ctx, span := i.start(ctx, spanName(fullMethod),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	return ctx, &call{
+		fullMethod:  fullMethod,
+		start:       time.Now(),
+		span:        span,
+		instruments: i.client,
+		attrs:       attrs,
+	}
+}
+
+// finishClient records the call, writes its access log and ends its span,
+// any error sets the Error status.
+func (i *Interceptors) finishClient(ctx context.Context, call *call, err error) {
+	call.finish(ctx, err)
+	i.accessLog(ctx, "grpc client call", call, err)
+	call.span.End(err)
+}
+
+// targetAttributes returns the server.address and server.port attributes of a client target,
+// e.g. dns:///localhost:4317.
+func targetAttributes(target string) []attribute.KeyValue {
+	if _, endpoint, found := strings.Cut(target, ":///"); found {
+		target = endpoint
+	}
+
+	host, port, err := net.SplitHostPort(target)
+	if err != nil {
+		return []attribute.KeyValue{semconv.ServerAddress(target)}
+	}
+
+	attrs := []attribute.KeyValue{semconv.ServerAddress(host)}
+
+	if number, err := strconv.Atoi(port); err == nil {
+		attrs = append(attrs, semconv.ServerPort(number))
+	}
+
+	return attrs
+}
+
+// clientStream wraps a grpc.ClientStream to record the message sizes and finish the call.
+type clientStream struct {
+	grpc.ClientStream
+
+	ctx           context.Context //nolint:containedctx
+	call          *call
+	interceptors  *Interceptors
+	serverStreams bool
+	once          sync.Once
+	done          chan struct{}
+}
+
+// SendMsg sends a request message and records its size.
+func (s *clientStream) SendMsg(msg any) error {
+	err := s.ClientStream.SendMsg(msg)
+	if err == nil {
+		s.call.request(s.ctx, msg)
+	}
+
+	return err //nolint:wrapcheck
+}
+
+// RecvMsg receives a response message and records its size. The call is finished at the end
+// of the stream, on failure, or after the response of a stream without server streaming.
+func (s *clientStream) RecvMsg(msg any) error {
+	err := s.ClientStream.RecvMsg(msg)
+
+	switch {
+	case err == nil:
+		s.call.response(s.ctx, msg)
+
+		if !s.serverStreams {
+			s.finish(nil)
+		}
+	case errors.Is(err, io.EOF):
+		s.finish(nil)
+	default:
+		s.finish(err)
+	}
+
+	return err //nolint:wrapcheck
+}
+
+// finish finishes the call once.
+func (s *clientStream) finish(err error) {
+	s.once.Do(func() {
+		s.interceptors.finishClient(s.ctx, s.call, err)
+		close(s.done)
+	})
+}
+
+// finishOnDone finishes the call with the context error when the stream context is done
+// before the call is finished, like a canceled stream which is not received anymore.
+func (s *clientStream) finishOnDone() {
+	select {
+	case <-s.ctx.Done():
+		s.finish(status.FromContextError(s.ctx.Err()).Err())
+	case <-s.done:
+	}
+}
@@ -0,0 +1,19 @@
+// Package grpcw provides OpenTelemetry instrumentation of gRPC servers and clients,
+// interceptors tracing, measuring and logging unary and streaming calls,
+// built on the tracew, metricw and slogw packages.
+//
+// Usage:
+//
+//	interceptors, err := grpcw.New(grpcw.Options{Metric: met, Skip: grpcw.HealthCheck})
+//	if err != nil {
+//		return fmt.Errorf("grpc interceptors: %w", err)
+//	}
+//	server := grpc.NewServer(
+//		grpc.ChainUnaryInterceptor(interceptors.UnaryServer()),
+//		grpc.ChainStreamInterceptor(interceptors.StreamServer()),
+//	)
+//	conn, err := grpc.NewClient(target,
+//		grpc.WithChainUnaryInterceptor(interceptors.UnaryClient()),
+//		grpc.WithChainStreamInterceptor(interceptors.StreamClient()),
+//	)
+package grpcw
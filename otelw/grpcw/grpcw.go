@@ -0,0 +1,304 @@
+package grpcw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/yolkhovyy/go-otelw/otelw/metricw"
+	"github.com/yolkhovyy/go-otelw/otelw/slogw"
+	"github.com/yolkhovyy/go-otelw/otelw/tracew"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// scopeName is the instrumentation scope of the spans of the grpcw package.
+const scopeName = "github.com/yolkhovyy/go-otelw/otelw/grpcw"
+
+// durationBuckets are the explicit bucket boundaries, in milliseconds, of the RPC duration histograms,
+// advised by the OpenTelemetry semantic conventions.
+var durationBuckets = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+
+// Options holds the options of the Interceptors.
+type Options struct {
+	// Metric records the RPC duration and message size histograms, no metrics are recorded if nil.
+	Metric *metricw.Metric
+
+	// Logger writes the access logs, slogw.DefaultLogger if nil.
+	Logger *slog.Logger
+
+	// Skip reports whether a call of the full method, e.g. /grpc.health.v1.Health/Check,
+	// is not instrumented, see HealthCheck. All calls are instrumented if nil.
+	Skip func(fullMethod string) bool
+
+	// LogPayloadSizes specifies whether the access logs include the total sizes
+	// of the request and response messages.
+	LogPayloadSizes bool
+
+	// TracerProvider starts the server and client spans, the global tracer provider if nil.
+	TracerProvider trace.TracerProvider
+}
+
+// HealthCheck reports whether the full method is a method of the gRPC health checking protocol.
+// It is intended as the Skip option, as health checks flood traces and logs.
+func HealthCheck(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/grpc.health.v1.Health/")
+}
+
+// instruments holds the RPC metric instruments of a side, server or client.
+type instruments struct {
+	duration     metric.Float64Histogram
+	requestSize  metric.Float64Histogram
+	responseSize metric.Float64Histogram
+}
+
+// Interceptors are gRPC server and client interceptors of unary and streaming calls.
+// Each call is made within a span named after the full method, with the RPC semantic
+// convention attributes, continuing the trace propagated in the call metadata by the
+// global propagator. The rpc.server.* and rpc.client.* duration and message size histograms
+// are recorded, and an access log record with the method, status code and duration is written.
+type Interceptors struct {
+	logger          *slog.Logger
+	skip            func(fullMethod string) bool
+	logPayloadSizes bool
+	provider        trace.TracerProvider
+	server          instruments
+	client          instruments
+}
+
+// New creates the Interceptors with the given options.
+// It returns an error if the metric instruments cannot be created.
+func New(options Options) (*Interceptors, error) {
+	interceptors := &Interceptors{
+		logger:          options.Logger,
+		skip:            options.Skip,
+		logPayloadSizes: options.LogPayloadSizes,
+		provider:        options.TracerProvider,
+	}
+
+	if interceptors.logger == nil {
+		interceptors.logger = slogw.DefaultLogger()
+	}
+
+	if interceptors.skip == nil {
+		interceptors.skip = func(string) bool { return false }
+	}
+
+	var errs [2]error
+
+	interceptors.server, errs[0] = newInstruments(options.Metric, "server")
+	interceptors.client, errs[1] = newInstruments(options.Metric, "client")
+
+	if err := errors.Join(errs[:]...); err != nil {
+		return nil, fmt.Errorf("grpcw new: %w", err)
+	}
+
+	return interceptors, nil
+}
+
+// start starts the span of a call with the tracer provider, or the global tracer provider if nil.
+func (i *Interceptors) start(
+	ctx context.Context,
+	sname string,
+	options ...trace.SpanStartOption,
+) (context.Context, tracew.Span) {
+	if i.provider == nil {
+		return tracew.Start(ctx, scopeName, sname, options...)
+	}
+
+	ctx, span := i.provider.Tracer(scopeName).Start(ctx, sname, options...) //nolint:spancheck
+
+	return ctx, tracew.Span{Span: span} //nolint:spancheck
+}
+
+// newInstruments creates the RPC instruments of a side, server or client.
+func newInstruments(met *metricw.Metric, side string) (instruments, error) {
+	var (
+		result instruments
+		errs   [3]error
+	)
+
+	result.duration, errs[0] = histogram(met, "rpc."+side+".duration", metricw.InstrumentOptions{
+		Description: "Measures the duration of inbound or outbound RPCs.",
+		Unit:        "ms",
+		Buckets:     durationBuckets,
+	})
+	result.requestSize, errs[1] = histogram(met, "rpc."+side+".request.size", metricw.InstrumentOptions{
+		Description: "Measures the size of RPC request messages (uncompressed).",
+		Unit:        "By",
+	})
+	result.responseSize, errs[2] = histogram(met, "rpc."+side+".response.size", metricw.InstrumentOptions{
+		Description: "Measures the size of RPC response messages (uncompressed).",
+		Unit:        "By",
+	})
+
+	return result, errors.Join(errs[:]...)
+}
+
+// histogram returns the histogram with the given name of met, or a no-op histogram if met is nil.
+func histogram( //nolint:ireturn
+	met *metricw.Metric,
+	name string,
+	options metricw.InstrumentOptions,
+) (metric.Float64Histogram, error) {
+	if met == nil {
+		return noop.Float64Histogram{}, nil
+	}
+
+	instrument, err := met.Histogram(name, options)
+	if err != nil {
+		return nil, fmt.Errorf("grpcw histogram %s: %w", name, err)
+	}
+
+	return instrument, nil
+}
+
+// methodAttributes returns the rpc.system, rpc.service and rpc.method attributes of a full method,
+// e.g. /grpc.health.v1.Health/Check.
+func methodAttributes(fullMethod string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.RPCSystemGRPC}
+
+	service, method, found := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !found {
+		return attrs
+	}
+
+	return append(attrs, semconv.RPCService(service), semconv.RPCMethod(method))
+}
+
+// spanName returns the span name of a full method, the method without its leading slash.
+func spanName(fullMethod string) string {
+	return strings.TrimPrefix(fullMethod, "/")
+}
+
+// serverError reports whether a status code is an error of the server,
+// following the OpenTelemetry semantic conventions of gRPC server span status.
+func serverError(code codes.Code) bool {
+	switch code { //nolint:exhaustive
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented,
+		codes.Internal, codes.Unavailable, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
+
+// messageSize returns the size of a protocol buffers message, or false if msg is not one.
+func messageSize(msg any) (int, bool) {
+	message, ok := msg.(proto.Message)
+	if !ok {
+		return 0, false
+	}
+
+	return proto.Size(message), true
+}
+
+// call holds the state of an instrumented call, shared by the server and client interceptors.
+// Streams may send and receive messages in different goroutines, the sizes are atomic.
+type call struct {
+	fullMethod   string
+	start        time.Time
+	span         tracew.Span
+	instruments  instruments
+	attrs        []attribute.KeyValue
+	requestSize  atomic.Int64
+	responseSize atomic.Int64
+}
+
+// request records the size of a request message.
+func (c *call) request(ctx context.Context, msg any) {
+	if size, ok := messageSize(msg); ok {
+		c.requestSize.Add(int64(size))
+		c.instruments.requestSize.Record(ctx, float64(size), metric.WithAttributes(c.attrs...))
+	}
+}
+
+// response records the size of a response message.
+func (c *call) response(ctx context.Context, msg any) {
+	if size, ok := messageSize(msg); ok {
+		c.responseSize.Add(int64(size))
+		c.instruments.responseSize.Record(ctx, float64(size), metric.WithAttributes(c.attrs...))
+	}
+}
+
+// finish records the duration and the status code of the call on its span.
+func (c *call) finish(ctx context.Context, err error) {
+	code := status.Code(err)
+	statusCode := semconv.RPCGRPCStatusCodeKey.Int(int(code))
+
+	c.span.SetAttributes(statusCode)
+	c.instruments.duration.Record(ctx, float64(time.Since(c.start))/float64(time.Millisecond),
+		metric.WithAttributes(append(c.attrs, statusCode)...))
+}
+
+// accessLog writes the access log record of a finished call, at the info level for OK,
+// error for server errors and warn for other status codes.
+func (i *Interceptors) accessLog(ctx context.Context, message string, call *call, err error) {
+	code := status.Code(err)
+
+	level := slog.LevelInfo
+
+	switch {
+	case serverError(code):
+		level = slog.LevelError
+	case code != codes.OK:
+		level = slog.LevelWarn
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", call.fullMethod),
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(call.start)),
+	}
+
+	if i.logPayloadSizes {
+		attrs = append(attrs,
+			slog.Int64("request_size", call.requestSize.Load()),
+			slog.Int64("response_size", call.responseSize.Load()),
+		)
+	}
+
+	if err != nil {
+		attrs = append(attrs, slogw.Err(err))
+	}
+
+	i.logger.LogAttrs(ctx, level, message, attrs...)
+}
+
+// metadataCarrier is a propagation.TextMapCarrier of gRPC metadata.
+type metadataCarrier metadata.MD
+
+// Get returns the first value of the key.
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// Set sets the value of the key.
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys returns the keys of the metadata.
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,371 @@
+package grpcw
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yolkhovyy/go-otelw/otelw/metricw"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var propagatorOnce sync.Once
+
+// setupPropagator sets the global trace context and baggage propagator, as tracew.Configure does.
+func setupPropagator() {
+	propagatorOnce.Do(func() {
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+			propagation.TraceContext{},
+			propagation.Baggage{},
+		))
+	})
+}
+
+// newRecorder returns a tracer provider of a single test, recording the ended spans.
+func newRecorder() (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), recorder
+}
+
+// endedSpans returns the ended spans of the grpcw package recorded by the recorder, by span kind.
+func endedSpans(recorder *tracetest.SpanRecorder) map[trace.SpanKind]sdktrace.ReadOnlySpan {
+	spans := make(map[trace.SpanKind]sdktrace.ReadOnlySpan)
+
+	for _, span := range recorder.Ended() {
+		if span.InstrumentationScope().Name == scopeName {
+			spans[span.SpanKind()] = span
+		}
+	}
+
+	return spans
+}
+
+// scrape returns the metrics exposed by the scrape handler of met.
+func scrape(t *testing.T, met *metricw.Metric) string {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	met.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	return recorder.Body.String()
+}
+
+// newHealthClient serves the health service over an in-memory connection,
+// both sides instrumented with the interceptors, and returns its client.
+func newHealthClient(t *testing.T, interceptors *Interceptors) healthpb.HealthClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(interceptors.UnaryServer()),
+		grpc.StreamInterceptor(interceptors.StreamServer()),
+	)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	go func() { _ = server.Serve(listener) }()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(interceptors.UnaryClient()),
+		grpc.WithStreamInterceptor(interceptors.StreamClient()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+func TestHealthCheck(t *testing.T) {
+	t.Parallel()
+
+	for fullMethod, want := range map[string]bool{
+		"/grpc.health.v1.Health/Check": true,
+		"/grpc.health.v1.Health/Watch": true,
+		"/echo.v1.Echo/Echo":           false,
+		"":                             false,
+	} {
+		assert.Equal(t, want, HealthCheck(fullMethod), fullMethod)
+	}
+}
+
+func TestTargetAttributes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		target string
+		want   []attribute.KeyValue
+	}{
+		{
+			target: "localhost:4317",
+			want:   []attribute.KeyValue{semconv.ServerAddress("localhost"), semconv.ServerPort(4317)},
+		},
+		{
+			target: "dns:///collector:4317",
+			want:   []attribute.KeyValue{semconv.ServerAddress("collector"), semconv.ServerPort(4317)},
+		},
+		{
+			target: "passthrough:///bufnet",
+			want:   []attribute.KeyValue{semconv.ServerAddress("bufnet")},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, targetAttributes(test.target), test.target)
+	}
+}
+
+//nolint:funlen
+func TestUnary(t *testing.T) {
+	t.Parallel()
+	setupPropagator()
+
+	type args struct {
+		service         string
+		logPayloadSizes bool
+	}
+
+	type want struct {
+		code       codes.Code
+		serverCode otelcodes.Code
+		clientCode otelcodes.Code
+		logs       []string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "ok",
+			want: want{
+				code:       codes.OK,
+				serverCode: otelcodes.Ok,
+				clientCode: otelcodes.Ok,
+				logs: []string{
+					`level=INFO msg="grpc server call" method=/grpc.health.v1.Health/Check code=OK`,
+					`level=INFO msg="grpc client call" method=/grpc.health.v1.Health/Check code=OK`,
+				},
+			},
+		},
+		{
+			name: "not found",
+			args: args{service: "unknown"},
+			want: want{
+				code:       codes.NotFound,
+				serverCode: otelcodes.Unset,
+				clientCode: otelcodes.Error,
+				logs: []string{
+					`level=WARN msg="grpc server call" method=/grpc.health.v1.Health/Check code=NotFound`,
+					`level=WARN msg="grpc client call" method=/grpc.health.v1.Health/Check code=NotFound`,
+				},
+			},
+		},
+		{
+			name: "payload sizes",
+			args: args{logPayloadSizes: true},
+			want: want{
+				code:       codes.OK,
+				serverCode: otelcodes.Ok,
+				clientCode: otelcodes.Ok,
+				logs:       []string{`request_size=0 response_size=2`},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			met, err := metricw.Configure(ctx, metricw.Config{Scrape: metricw.ScrapeConfig{Enable: true}}, nil)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, met.Shutdown(ctx))
+			}()
+
+			var logs bytes.Buffer
+
+			provider, recorder := newRecorder()
+
+			interceptors, err := New(Options{
+				Metric:          met,
+				Logger:          slog.New(slog.NewTextHandler(&logs, nil)),
+				LogPayloadSizes: test.args.logPayloadSizes,
+				TracerProvider:  provider,
+			})
+			require.NoError(t, err)
+
+			client := newHealthClient(t, interceptors)
+
+			ctx, parent := provider.Tracer("test").Start(ctx, "parent")
+			defer parent.End()
+
+			_, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: test.args.service})
+			assert.Equal(t, test.want.code, status.Code(err))
+
+			spans := endedSpans(recorder)
+			require.Len(t, spans, 2)
+
+			clientSpan, serverSpan := spans[trace.SpanKindClient], spans[trace.SpanKindServer]
+			require.NotNil(t, clientSpan)
+			require.NotNil(t, serverSpan)
+
+			assert.Equal(t, "grpc.health.v1.Health/Check", serverSpan.Name())
+			assert.Equal(t, parent.SpanContext().SpanID(), clientSpan.Parent().SpanID())
+			assert.Equal(t, clientSpan.SpanContext().SpanID(), serverSpan.Parent().SpanID())
+			assert.Equal(t, test.want.serverCode, serverSpan.Status().Code)
+			assert.Equal(t, test.want.clientCode, clientSpan.Status().Code)
+
+			for _, span := range []sdktrace.ReadOnlySpan{clientSpan, serverSpan} {
+				assert.Contains(t, span.Attributes(), semconv.RPCSystemGRPC)
+				assert.Contains(t, span.Attributes(), semconv.RPCService("grpc.health.v1.Health"))
+				assert.Contains(t, span.Attributes(), semconv.RPCMethod("Check"))
+				assert.Contains(t, span.Attributes(), semconv.RPCGRPCStatusCodeKey.Int(int(test.want.code)))
+			}
+
+			metrics := scrape(t, met)
+			assert.Contains(t, metrics, "rpc_server_duration_milliseconds_count{")
+			assert.Contains(t, metrics, "rpc_client_duration_milliseconds_count{")
+			assert.Contains(t, metrics, "rpc_server_request_size_bytes_count{")
+
+			for _, log := range test.want.logs {
+				assert.Contains(t, logs.String(), log)
+			}
+		})
+	}
+}
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+	setupPropagator()
+
+	provider, recorder := newRecorder()
+
+	interceptors, err := New(Options{
+		Logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+		TracerProvider: provider,
+	})
+	require.NoError(t, err)
+
+	client := newHealthClient(t, interceptors)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	defer parent.End()
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	response, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, response.GetStatus())
+
+	cancel()
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Canceled, status.Code(err))
+
+	require.Eventually(t, func() bool { return len(endedSpans(recorder)) == 2 }, time.Second, 10*time.Millisecond)
+
+	spans := endedSpans(recorder)
+	assert.Equal(t, "grpc.health.v1.Health/Watch", spans[trace.SpanKindServer].Name())
+	assert.Equal(t, otelcodes.Unset, spans[trace.SpanKindServer].Status().Code)
+	assert.Equal(t, otelcodes.Error, spans[trace.SpanKindClient].Status().Code)
+}
+
+func TestStreamCanceled(t *testing.T) {
+	t.Parallel()
+	setupPropagator()
+
+	provider, recorder := newRecorder()
+
+	interceptors, err := New(Options{
+		Logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+		TracerProvider: provider,
+	})
+	require.NoError(t, err)
+
+	client := newHealthClient(t, interceptors)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	defer parent.End()
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	_, err = client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	// The stream is canceled without being received.
+	cancel()
+
+	require.Eventually(t, func() bool {
+		_, ended := endedSpans(recorder)[trace.SpanKindClient]
+
+		return ended
+	}, time.Second, 10*time.Millisecond)
+
+	span := endedSpans(recorder)[trace.SpanKindClient]
+	assert.Equal(t, otelcodes.Error, span.Status().Code)
+	assert.Contains(t, span.Attributes(), semconv.RPCGRPCStatusCodeKey.Int(int(codes.Canceled)))
+}
+
+func TestSkip(t *testing.T) {
+	t.Parallel()
+	setupPropagator()
+
+	var logs bytes.Buffer
+
+	provider, recorder := newRecorder()
+
+	interceptors, err := New(Options{
+		Logger:         slog.New(slog.NewTextHandler(&logs, nil)),
+		Skip:           HealthCheck,
+		TracerProvider: provider,
+	})
+	require.NoError(t, err)
+
+	client := newHealthClient(t, interceptors)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	defer parent.End()
+
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	assert.Empty(t, endedSpans(recorder))
+	assert.Empty(t, logs.String())
+}
@@ -0,0 +1,135 @@
+package grpcw
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServer returns the unary server interceptor, see Interceptors.
+func (i *Interceptors) UnaryServer() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if i.skip(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, call := i.startServer(ctx, info.FullMethod)
+
+		call.request(ctx, req)
+
+		resp, err := handler(ctx, req)
+		if err == nil {
+			call.response(ctx, resp)
+		}
+
+		i.finishServer(ctx, call, err)
+
+		return resp, err
+	}
+}
+
+// StreamServer returns the stream server interceptor, see Interceptors.
+func (i *Interceptors) StreamServer() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if i.skip(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		ctx, call := i.startServer(stream.Context(), info.FullMethod)
+
+		err := handler(srv, &serverStream{ServerStream: stream, ctx: ctx, call: call})
+
+		i.finishServer(ctx, call, err)
+
+		return err
+	}
+}
+
+// startServer starts the server span of a call, continuing the trace propagated in the incoming metadata.
+func (i *Interceptors) startServer(ctx context.Context, fullMethod string) (context.Context, *call) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+	attrs := methodAttributes(fullMethod)
+
+	var spanAttrs []attribute.KeyValue
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			spanAttrs = append(spanAttrs, semconv.ClientAddress(host))
+		}
+	}
+
+	ctx, span := i.start(ctx, spanName(fullMethod),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(spanAttrs...),
+	)
+
+	return ctx, &call{
+		fullMethod:  fullMethod,
+		start:       time.Now(),
+		span:        span,
+		instruments: i.server,
+		attrs:       attrs,
+	}
+}
+
+// finishServer records the call, writes its access log and ends its span.
+// Only server errors set the Error status, client errors keep it Unset.
+func (i *Interceptors) finishServer(ctx context.Context, call *call, err error) {
+	call.finish(ctx, err)
+	i.accessLog(ctx, "grpc server call", call, err)
+
+	switch code := status.Code(err); {
+	case serverError(code):
+		call.span.End(err)
+	case err != nil:
+		call.span.EndWithStatus(otelcodes.Unset, "")
+	default:
+		call.span.End(nil)
+	}
+}
+
+// serverStream wraps a grpc.ServerStream to carry the span context and record the message sizes.
+type serverStream struct {
+	grpc.ServerStream
+
+	ctx  context.Context //nolint:containedctx
+	call *call
+}
+
+// Context returns the context of the stream, carrying the span.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// RecvMsg receives a request message and records its size.
+func (s *serverStream) RecvMsg(msg any) error {
+	err := s.ServerStream.RecvMsg(msg)
+	if err == nil {
+		s.call.request(s.ctx, msg)
+	}
+
+	return err //nolint:wrapcheck
+}
+
+// SendMsg sends a response message and records its size.
+func (s *serverStream) SendMsg(msg any) error {
+	err := s.ServerStream.SendMsg(msg)
+	if err == nil {
+		s.call.response(s.ctx, msg)
+	}
+
+	return err //nolint:wrapcheck
+}
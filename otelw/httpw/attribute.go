@@ -0,0 +1,150 @@
+package httpw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/yolkhovyy/go-otelw/otelw/metricw"
+	"github.com/yolkhovyy/go-otelw/otelw/tracew"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// scopeName is the instrumentation scope of the spans of the httpw package.
+const scopeName = "github.com/yolkhovyy/go-otelw/otelw/httpw"
+
+// durationBuckets are the explicit bucket boundaries, in seconds, of the HTTP duration histograms,
+// advised by the OpenTelemetry semantic conventions.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10}
+
+// knownMethods are the HTTP methods kept as the http.request.method attribute, other methods become _OTHER.
+var knownMethods = map[string]bool{
+	http.MethodConnect: true,
+	http.MethodDelete:  true,
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPatch:   true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodTrace:   true,
+}
+
+// method returns the http.request.method attribute of a request method,
+// unknown methods are recorded as _OTHER to bound the cardinality.
+func method(name string) attribute.KeyValue {
+	if knownMethods[name] {
+		return semconv.HTTPRequestMethodKey.String(name)
+	}
+
+	return semconv.HTTPRequestMethodOther
+}
+
+// serverAttributes returns the server.address and server.port attributes of a request URL,
+// the port defaults to the one of the scheme.
+func serverAttributes(requestURL *url.URL) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.ServerAddress(requestURL.Hostname())}
+
+	if port, err := strconv.Atoi(requestURL.Port()); err == nil {
+		return append(attrs, semconv.ServerPort(port))
+	}
+
+	switch requestURL.Scheme {
+	case "http":
+		attrs = append(attrs, semconv.ServerPort(80)) //nolint:mnd
+	case "https":
+		attrs = append(attrs, semconv.ServerPort(443)) //nolint:mnd
+	}
+
+	return attrs
+}
+
+// fullURL returns the url.full attribute of a request URL with redacted credentials.
+func fullURL(requestURL *url.URL) attribute.KeyValue {
+	redacted := *requestURL
+	if redacted.User != nil {
+		redacted.User = url.UserPassword("REDACTED", "REDACTED")
+	}
+
+	return semconv.URLFull(redacted.String())
+}
+
+// protocolVersion returns the network.protocol.version attribute of an HTTP protocol, e.g. 1.1 or 2.
+func protocolVersion(major, minor int) attribute.KeyValue {
+	if minor == 0 && major > 1 {
+		return semconv.NetworkProtocolVersion(strconv.Itoa(major))
+	}
+
+	return semconv.NetworkProtocolVersion(fmt.Sprintf("%d.%d", major, minor))
+}
+
+// errorType returns the error.type attribute of a failed request,
+// the Go type of the error or the status code of an error response.
+func errorType(err error, statusCode int) (attribute.KeyValue, bool) {
+	switch {
+	case err != nil:
+		return semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err)), true
+	case statusCode >= http.StatusBadRequest:
+		return semconv.ErrorTypeKey.String(strconv.Itoa(statusCode)), true
+	default:
+		return attribute.KeyValue{}, false
+	}
+}
+
+// start starts a span with the tracer provider, or the global tracer provider if nil.
+func start(
+	ctx context.Context,
+	provider trace.TracerProvider,
+	sname string,
+	options ...trace.SpanStartOption,
+) (context.Context, tracew.Span) {
+	if provider == nil {
+		return tracew.Start(ctx, scopeName, sname, options...)
+	}
+
+	ctx, span := provider.Tracer(scopeName).Start(ctx, sname, options...) //nolint:spancheck
+
+	return ctx, tracew.Span{Span: span} //nolint:spancheck
+}
+
+// histogram returns the histogram with the given name of met, or a no-op histogram if met is nil.
+func histogram( //nolint:ireturn
+	met *metricw.Metric,
+	name string,
+	options metricw.InstrumentOptions,
+) (metric.Float64Histogram, error) {
+	if met == nil {
+		return noop.Float64Histogram{}, nil
+	}
+
+	instrument, err := met.Histogram(name, options)
+	if err != nil {
+		return nil, fmt.Errorf("httpw histogram %s: %w", name, err)
+	}
+
+	return instrument, nil
+}
+
+// upDownCounter returns the up-down counter with the given name of met, or a no-op counter if met is nil.
+func upDownCounter( //nolint:ireturn
+	met *metricw.Metric,
+	name string,
+	options metricw.InstrumentOptions,
+) (metric.Float64UpDownCounter, error) {
+	if met == nil {
+		return noop.Float64UpDownCounter{}, nil
+	}
+
+	instrument, err := met.UpDownCounter(name, options)
+	if err != nil {
+		return nil, fmt.Errorf("httpw up-down counter %s: %w", name, err)
+	}
+
+	return instrument, nil
+}
@@ -0,0 +1,18 @@
+// Package httpw provides OpenTelemetry instrumentation of net/http clients and servers,
+// tracing, metrics and logs of outgoing and served requests, built on the tracew,
+// metricw and slogw packages.
+//
+// Usage:
+//
+//	transport, err := httpw.NewTransport(httpw.TransportOptions{Metric: met})
+//	if err != nil {
+//		return fmt.Errorf("http transport: %w", err)
+//	}
+//	client := &http.Client{Transport: transport}
+//
+//	middleware, err := httpw.NewMiddleware(httpw.ServerOptions{Metric: met})
+//	if err != nil {
+//		return fmt.Errorf("http middleware: %w", err)
+//	}
+//	server := &http.Server{Handler: middleware(mux)}
+package httpw
@@ -0,0 +1,388 @@
+package httpw
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/yolkhovyy/go-otelw/otelw/metricw"
+	"github.com/yolkhovyy/go-otelw/otelw/slogw"
+	"github.com/yolkhovyy/go-otelw/otelw/tracew"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RouteFunc returns the route template of a served request, e.g. /users/{id},
+// or an empty string if the route is unknown. It is called after the request is served,
+// when routers have matched the route.
+//
+// Route functions of popular routers:
+//
+//	// github.com/go-chi/chi/v5, the middleware must be added with router.Use
+//	func(r *http.Request) string { return chi.RouteContext(r.Context()).RoutePattern() }
+//
+//	// github.com/gorilla/mux, the middleware must be added with router.Use
+//	func(r *http.Request) string {
+//		route := mux.CurrentRoute(r)
+//		if route == nil {
+//			return ""
+//		}
+//		template, _ := route.GetPathTemplate()
+//		return template
+//	}
+type RouteFunc func(request *http.Request) string
+
+// ServerOptions holds the options of a server Middleware.
+type ServerOptions struct {
+	// Metric records the server duration, active requests and body size metrics,
+	// no metrics are recorded if nil.
+	Metric *metricw.Metric
+
+	// Logger logs the served requests, slogw.DefaultLogger if nil.
+	Logger *slog.Logger
+
+	// Route returns the route template of a request, ServeMuxRoute if nil.
+	Route RouteFunc
+
+	// TracerProvider starts the server spans, the global tracer provider if nil.
+	TracerProvider trace.TracerProvider
+}
+
+// ServeMuxRoute returns the route template of a request served by http.ServeMux,
+// the matched pattern without its method and host, e.g. /users/{id}.
+func ServeMuxRoute(request *http.Request) string {
+	pattern := request.Pattern
+
+	// Patterns are [METHOD ][HOST]/[PATH].
+	if _, path, found := strings.Cut(pattern, " "); found {
+		pattern = path
+	}
+
+	if slash := strings.IndexByte(pattern, '/'); slash > 0 {
+		pattern = pattern[slash:]
+	}
+
+	return pattern
+}
+
+// serverInstruments holds the server metric instruments.
+type serverInstruments struct {
+	duration       metric.Float64Histogram
+	activeRequests metric.Float64UpDownCounter
+	requestSize    metric.Float64Histogram
+	responseSize   metric.Float64Histogram
+}
+
+// NewMiddleware creates a net/http middleware, e.g. for http.Server.Handler with http.ServeMux,
+// or the Use methods of chi and gorilla/mux, whose routes are known only within the router.
+// Each request is served within a server span continuing
+// the trace of the global propagator headers, named after the request method and route,
+// with the HTTP semantic convention attributes. Only 5xx responses set the Error status.
+// The RED metrics http.server.request.duration, http.server.active_requests and the body sizes
+// are recorded, and a log record with the method, route, status and duration is written
+// at the info, warn (4xx) or error (5xx) level. Panics are recorded and propagated.
+// It returns an error if the metric instruments cannot be created.
+func NewMiddleware(options ServerOptions) (func(next http.Handler) http.Handler, error) {
+	logger := options.Logger
+	if logger == nil {
+		logger = slogw.DefaultLogger()
+	}
+
+	route := options.Route
+	if route == nil {
+		route = ServeMuxRoute
+	}
+
+	var (
+		instruments serverInstruments
+		errs        [4]error
+	)
+
+	instruments.duration, errs[0] = histogram(options.Metric, "http.server.request.duration", metricw.InstrumentOptions{
+		Description: "Duration of HTTP server requests.",
+		Unit:        "s",
+		Buckets:     durationBuckets,
+	})
+	instruments.activeRequests, errs[1] = upDownCounter(options.Metric, "http.server.active_requests",
+		metricw.InstrumentOptions{
+			Description: "Number of active HTTP server requests.",
+			Unit:        "{request}",
+		})
+	instruments.requestSize, errs[2] = histogram(options.Metric, "http.server.request.body.size", metricw.InstrumentOptions{
+		Description: "Size of HTTP server request bodies.",
+		Unit:        "By",
+	})
+	instruments.responseSize, errs[3] = histogram(options.Metric, "http.server.response.body.size",
+		metricw.InstrumentOptions{
+			Description: "Size of HTTP server response bodies.",
+			Unit:        "By",
+		})
+
+	if err := errors.Join(errs[:]...); err != nil {
+		return nil, fmt.Errorf("httpw new middleware: %w", err)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return &serverHandler{
+			next:        next,
+			logger:      logger,
+			route:       route,
+			provider:    options.TracerProvider,
+			instruments: instruments,
+		}
+	}, nil
+}
+
+// serverHandler is the http.Handler of the server middleware.
+type serverHandler struct {
+	next        http.Handler
+	logger      *slog.Logger
+	route       RouteFunc
+	provider    trace.TracerProvider
+	instruments serverInstruments
+}
+
+// ServeHTTP serves the request within a server span, see NewMiddleware.
+func (h *serverHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	ctx := otel.GetTextMapPropagator().Extract(request.Context(), propagation.HeaderCarrier(request.Header))
+
+	scheme := semconv.URLScheme("http")
+	if request.TLS != nil {
+		scheme = semconv.URLScheme("https")
+	}
+
+	activeAttrs := metric.WithAttributes(method(request.Method), scheme)
+
+	ctx, span := start(ctx, h.provider, request.Method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			method(request.Method),
+			scheme,
+			semconv.URLPath(request.URL.Path),
+			protocolVersion(request.ProtoMajor, request.ProtoMinor),
+		),
+		trace.WithAttributes(hostAttributes(request)...),
+	)
+
+	h.instruments.activeRequests.Add(ctx, 1, activeAttrs)
+	defer h.instruments.activeRequests.Add(ctx, -1, activeAttrs)
+
+	served := &served{
+		request: request.WithContext(ctx),
+		writer:  &responseWriter{ResponseWriter: writer},
+		start:   time.Now(),
+	}
+
+	if request.Body != nil && request.Body != http.NoBody {
+		served.body = &requestBody{ReadCloser: request.Body}
+		served.request.Body = served.body
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			span.RecordError(fmt.Errorf("%w: %v", tracew.ErrPanic, recovered), trace.WithStackTrace(true))
+			served.writer.status = http.StatusInternalServerError
+			h.finish(served, span, scheme)
+
+			panic(recovered)
+		}
+	}()
+
+	h.next.ServeHTTP(served.writer, served.request)
+
+	h.finish(served, span, scheme)
+}
+
+// served holds the state of a served request.
+type served struct {
+	request *http.Request
+	writer  *responseWriter
+	body    *requestBody
+	start   time.Time
+}
+
+// finish records the metrics, logs the request and ends the span of a served request.
+func (h *serverHandler) finish(served *served, span tracew.Span, scheme attribute.KeyValue) {
+	ctx := served.request.Context()
+	duration := time.Since(served.start)
+	status := served.writer.Status()
+	route := h.route(served.request)
+
+	attrs := []attribute.KeyValue{
+		method(served.request.Method),
+		scheme,
+		protocolVersion(served.request.ProtoMajor, served.request.ProtoMinor),
+	}
+
+	// The status of a hijacked connection is unknown.
+	statusAttr := slog.Bool("hijacked", true)
+
+	if status != 0 {
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(status))
+		statusAttr = slog.Int("status", status)
+	}
+
+	if route != "" {
+		attrs = append(attrs, semconv.HTTPRoute(route))
+		span.SetName(served.request.Method + " " + route)
+	}
+
+	if status >= http.StatusInternalServerError {
+		if errType, failed := errorType(nil, status); failed {
+			attrs = append(attrs, errType)
+		}
+	}
+
+	span.SetAttributes(attrs...)
+
+	options := metric.WithAttributes(attrs...)
+
+	h.instruments.duration.Record(ctx, duration.Seconds(), options)
+	h.instruments.responseSize.Record(ctx, float64(served.writer.written), options)
+
+	if served.body != nil {
+		h.instruments.requestSize.Record(ctx, float64(served.body.read), options)
+	}
+
+	level := slog.LevelInfo
+
+	switch {
+	case status >= http.StatusInternalServerError:
+		level = slog.LevelError
+	case status >= http.StatusBadRequest:
+		level = slog.LevelWarn
+	}
+
+	h.logger.LogAttrs(ctx, level, "http server request",
+		slog.String("method", served.request.Method),
+		slog.String("route", route),
+		slog.String("path", served.request.URL.Path),
+		statusAttr,
+		slog.Duration("duration", duration),
+	)
+
+	// Server spans of 4xx responses keep the Unset status, the client made the error.
+	switch {
+	case status >= http.StatusInternalServerError:
+		span.EndWithStatus(codes.Error, http.StatusText(status))
+	case status >= http.StatusBadRequest:
+		span.EndWithStatus(codes.Unset, "")
+	default:
+		span.End(nil)
+	}
+}
+
+// hostAttributes returns the server.address, server.port, client.address and
+// user_agent.original attributes of a request.
+func hostAttributes(request *http.Request) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+
+	if host, _, err := net.SplitHostPort(request.Host); err == nil {
+		attrs = append(attrs, semconv.ServerAddress(host))
+	} else if request.Host != "" {
+		attrs = append(attrs, semconv.ServerAddress(request.Host))
+	}
+
+	if client, _, err := net.SplitHostPort(request.RemoteAddr); err == nil {
+		attrs = append(attrs, semconv.ClientAddress(client))
+	}
+
+	if userAgent := request.UserAgent(); userAgent != "" {
+		attrs = append(attrs, semconv.UserAgentOriginal(userAgent))
+	}
+
+	return attrs
+}
+
+// responseWriter wraps an http.ResponseWriter to capture the status code and the number of written bytes.
+type responseWriter struct {
+	http.ResponseWriter
+
+	status   int
+	written  int64
+	hijacked bool
+}
+
+// WriteHeader captures the status code and writes it. Informational statuses other than
+// 101 Switching Protocols, e.g. 103 Early Hints, are not captured, they precede the final status.
+func (w *responseWriter) WriteHeader(status int) {
+	informational := status >= 100 && status < http.StatusOK && status != http.StatusSwitchingProtocols
+
+	if w.status == 0 && !informational {
+		w.status = status
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write counts the written bytes, an implicit status is 200 OK.
+func (w *responseWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	n, err := w.ResponseWriter.Write(data)
+	w.written += int64(n)
+
+	return n, err //nolint:wrapcheck
+}
+
+// Flush flushes the response if the wrapped writer supports it.
+func (w *responseWriter) Flush() {
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack hijacks the connection if the wrapped writer supports it, e.g. for WebSocket upgrades,
+// otherwise it returns an error wrapping http.ErrNotSupported. The status written to a hijacked
+// connection is unknown, it is not recorded unless written before with WriteHeader.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, buffer, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+
+	w.hijacked = true
+
+	return conn, buffer, nil
+}
+
+// Unwrap returns the wrapped writer, used by http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Status returns the status code of the response, 200 OK if none was written,
+// or 0 if none was written before the connection was hijacked.
+func (w *responseWriter) Status() int {
+	if w.status == 0 && !w.hijacked {
+		return http.StatusOK
+	}
+
+	return w.status
+}
+
+// requestBody wraps a request body to count the read bytes.
+type requestBody struct {
+	io.ReadCloser
+
+	read int64
+}
+
+// Read reads from the body and counts the read bytes.
+func (b *requestBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+
+	return n, err //nolint:wrapcheck
+}
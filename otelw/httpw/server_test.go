@@ -0,0 +1,303 @@
+package httpw
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yolkhovyy/go-otelw/otelw/metricw"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestServeMuxRoute(t *testing.T) {
+	t.Parallel()
+
+	for pattern, want := range map[string]string{
+		"":                            "",
+		"/":                           "/",
+		"/users/{id}":                 "/users/{id}",
+		"GET /users/{id}":             "/users/{id}",
+		"example.com/users/{id}":      "/users/{id}",
+		"POST example.com/users/{id}": "/users/{id}",
+	} {
+		assert.Equal(t, want, ServeMuxRoute(&http.Request{Pattern: pattern}), pattern)
+	}
+}
+
+//nolint:funlen
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+	setupPropagator()
+
+	type args struct {
+		method string
+		target string
+		body   string
+		route  RouteFunc
+	}
+
+	type want struct {
+		panics   bool
+		status   int
+		body     string
+		spanName string
+		code     codes.Code
+		route    string
+		logs     string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "ok",
+			args: args{method: http.MethodPost, target: "/users/42", body: "hello"},
+			want: want{
+				status:   http.StatusOK,
+				body:     "hello",
+				spanName: "POST /users/{id}",
+				code:     codes.Ok,
+				route:    "/users/{id}",
+				logs:     `level=INFO msg="http server request" method=POST route=/users/{id} path=/users/42 status=200`,
+			},
+		},
+		{
+			name: "not found",
+			args: args{method: http.MethodGet, target: "/missing"},
+			want: want{
+				status:   http.StatusNotFound,
+				body:     "404 page not found\n",
+				spanName: "GET",
+				code:     codes.Unset,
+				logs:     `level=WARN msg="http server request" method=GET route="" path=/missing status=404`,
+			},
+		},
+		{
+			name: "server error",
+			args: args{method: http.MethodGet, target: "/fail"},
+			want: want{
+				status:   http.StatusInternalServerError,
+				spanName: "GET /fail",
+				code:     codes.Error,
+				route:    "/fail",
+				logs:     `level=ERROR msg="http server request" method=GET route=/fail path=/fail status=500`,
+			},
+		},
+		{
+			name: "custom route",
+			args: args{
+				method: http.MethodGet,
+				target: "/users/7",
+				route:  func(*http.Request) string { return "/users/:id" },
+			},
+			want: want{
+				status:   http.StatusOK,
+				spanName: "GET /users/:id",
+				code:     codes.Ok,
+				route:    "/users/:id",
+				logs:     `route=/users/:id`,
+			},
+		},
+		{
+			name: "panic",
+			args: args{method: http.MethodGet, target: "/panic"},
+			want: want{
+				panics:   true,
+				status:   http.StatusInternalServerError,
+				spanName: "GET /panic",
+				code:     codes.Error,
+				route:    "/panic",
+				logs:     `level=ERROR msg="http server request" method=GET route=/panic path=/panic status=500`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			met, err := metricw.Configure(ctx, metricw.Config{Scrape: metricw.ScrapeConfig{Enable: true}}, nil)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, met.Shutdown(ctx))
+			}()
+
+			var logs bytes.Buffer
+
+			provider, recorder := newRecorder()
+
+			middleware, err := NewMiddleware(ServerOptions{
+				Metric:         met,
+				Logger:         slog.New(slog.NewTextHandler(&logs, nil)),
+				Route:          test.args.route,
+				TracerProvider: provider,
+			})
+			require.NoError(t, err)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /users/{id}", func(writer http.ResponseWriter, request *http.Request) {
+				body, _ := io.ReadAll(request.Body)
+				_, _ = writer.Write(body)
+			})
+			mux.HandleFunc("GET /users/{id}", func(http.ResponseWriter, *http.Request) {})
+			mux.HandleFunc("/fail", func(writer http.ResponseWriter, _ *http.Request) {
+				writer.WriteHeader(http.StatusInternalServerError)
+			})
+			mux.HandleFunc("/panic", func(http.ResponseWriter, *http.Request) {
+				panic("test panic")
+			})
+
+			ctx, parent := provider.Tracer("test").Start(ctx, "parent")
+			defer parent.End()
+
+			request := httptest.NewRequest(test.args.method, test.args.target, strings.NewReader(test.args.body))
+			otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(request.Header))
+
+			response := httptest.NewRecorder()
+			serve := func() { middleware(mux).ServeHTTP(response, request) }
+
+			if test.want.panics {
+				assert.PanicsWithValue(t, "test panic", serve)
+			} else {
+				serve()
+				assert.Equal(t, test.want.status, response.Code)
+				assert.Equal(t, test.want.body, response.Body.String())
+			}
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+
+			span := spans[0]
+			assert.Equal(t, test.want.spanName, span.Name())
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+			assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+			assert.Equal(t, test.want.code, span.Status().Code)
+			assert.Contains(t, span.Attributes(), semconv.HTTPResponseStatusCode(test.want.status))
+
+			if test.want.route != "" {
+				assert.Contains(t, span.Attributes(), semconv.HTTPRoute(test.want.route))
+			}
+
+			metrics := scrape(t, met)
+			assert.Contains(t, metrics, "http_server_request_duration_seconds_count{")
+			assert.Contains(t, metrics, "http_server_active_requests{")
+			assert.Contains(t, logs.String(), test.want.logs)
+		})
+	}
+}
+
+func TestMiddlewareEarlyHints(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer
+
+	middleware, err := NewMiddleware(ServerOptions{
+		Logger: slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+	require.NoError(t, err)
+
+	server := httptest.NewServer(middleware(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.Header().Set("Link", "</style.css>; rel=preload; as=style")
+		writer.WriteHeader(http.StatusEarlyHints)
+		_, _ = io.WriteString(writer, "hinted")
+	})))
+	defer server.Close()
+
+	response, err := server.Client().Get(server.URL)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "hinted", string(body))
+
+	// Close waits for the request to be logged.
+	server.Close()
+
+	assert.Contains(t, logs.String(), "status=200")
+}
+
+func TestMiddlewareHijack(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer
+
+	provider, recorder := newRecorder()
+
+	middleware, err := NewMiddleware(ServerOptions{
+		Logger:         slog.New(slog.NewTextHandler(&logs, nil)),
+		TracerProvider: provider,
+	})
+	require.NoError(t, err)
+
+	var hijackErr error
+
+	handler := middleware(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		conn, buffer, err := http.NewResponseController(writer).Hijack()
+		if err != nil {
+			hijackErr = err
+
+			return
+		}
+		defer conn.Close()
+
+		_, err = buffer.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 8\r\nConnection: close\r\n\r\nhijacked")
+		if err == nil {
+			err = buffer.Flush()
+		}
+
+		hijackErr = err
+	}))
+
+	// The server does not wait for the handlers of hijacked connections.
+	served := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		defer close(served)
+
+		handler.ServeHTTP(writer, request)
+	}))
+	defer server.Close()
+
+	response, err := server.Client().Get(server.URL)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+
+	<-served
+
+	require.NoError(t, hijackErr)
+	assert.Equal(t, "hijacked", string(body))
+	assert.Contains(t, logs.String(), "hijacked=true")
+	assert.NotContains(t, logs.String(), "status=")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	for _, attr := range spans[0].Attributes() {
+		assert.NotEqual(t, semconv.HTTPResponseStatusCodeKey, attr.Key)
+	}
+
+	_, _, err = (&responseWriter{ResponseWriter: httptest.NewRecorder()}).Hijack()
+	require.ErrorIs(t, err, http.ErrNotSupported)
+}
@@ -0,0 +1,244 @@
+package httpw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/yolkhovyy/go-otelw/otelw/metricw"
+	"github.com/yolkhovyy/go-otelw/otelw/slogw"
+	"github.com/yolkhovyy/go-otelw/otelw/tracew"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TransportOptions holds the options of a Transport.
+type TransportOptions struct {
+	// Base is the transport making the requests, http.DefaultTransport if nil.
+	Base http.RoundTripper
+
+	// Metric records the client duration and body size histograms, no metrics are recorded if nil.
+	Metric *metricw.Metric
+
+	// Logger logs failed requests, slogw.DefaultLogger if nil.
+	Logger *slog.Logger
+
+	// TracerProvider starts the client spans, the global tracer provider if nil.
+	TracerProvider trace.TracerProvider
+}
+
+// Transport is an http.RoundTripper tracing outgoing requests. Each request is made
+// within a client span named after the request method, with the HTTP semantic convention
+// attributes, and the global propagator headers are injected into the request.
+// The request duration and the request and response body sizes are recorded
+// as the http.client.* histograms, failed requests are logged with the span context.
+// The span ends when the response body is read to the end or closed,
+// or with the response of a protocol upgrade.
+type Transport struct {
+	base         http.RoundTripper
+	logger       *slog.Logger
+	provider     trace.TracerProvider
+	duration     metric.Float64Histogram
+	requestSize  metric.Float64Histogram
+	responseSize metric.Float64Histogram
+}
+
+var _ http.RoundTripper = (*Transport)(nil)
+
+// NewTransport creates a Transport with the given options.
+// It returns an error if the histograms cannot be created.
+func NewTransport(options TransportOptions) (*Transport, error) {
+	transport := &Transport{
+		base:     options.Base,
+		logger:   options.Logger,
+		provider: options.TracerProvider,
+	}
+
+	if transport.base == nil {
+		transport.base = http.DefaultTransport
+	}
+
+	if transport.logger == nil {
+		transport.logger = slogw.DefaultLogger()
+	}
+
+	var errs [3]error
+
+	transport.duration, errs[0] = histogram(options.Metric, "http.client.request.duration", metricw.InstrumentOptions{
+		Description: "Duration of HTTP client requests.",
+		Unit:        "s",
+		Buckets:     durationBuckets,
+	})
+	transport.requestSize, errs[1] = histogram(options.Metric, "http.client.request.body.size", metricw.InstrumentOptions{
+		Description: "Size of HTTP client request bodies.",
+		Unit:        "By",
+	})
+	transport.responseSize, errs[2] = histogram(options.Metric, "http.client.response.body.size", metricw.InstrumentOptions{
+		Description: "Size of HTTP client response bodies.",
+		Unit:        "By",
+	})
+
+	if err := errors.Join(errs[:]...); err != nil {
+		return nil, fmt.Errorf("httpw new transport: %w", err)
+	}
+
+	return transport, nil
+}
+
+// RoundTrip makes the request within a client span, see Transport.
+func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	attrs := append([]attribute.KeyValue{method(request.Method)}, serverAttributes(request.URL)...)
+
+	ctx, span := start(request.Context(), t.provider, request.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(fullURL(request.URL)),
+	)
+
+	if userAgent := request.UserAgent(); userAgent != "" {
+		span.SetAttributes(semconv.UserAgentOriginal(userAgent))
+	}
+
+	// A RoundTripper must not modify the request, the propagation headers are set on a clone.
+	request = request.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(request.Header))
+
+	start := time.Now()
+
+	response, err := t.base.RoundTrip(request)
+
+	if response != nil {
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(response.StatusCode))
+		span.SetAttributes(
+			semconv.HTTPResponseStatusCode(response.StatusCode),
+			protocolVersion(response.ProtoMajor, response.ProtoMinor),
+		)
+	}
+
+	var statusCode int
+	if err == nil {
+		statusCode = response.StatusCode
+	}
+
+	if errType, failed := errorType(err, statusCode); failed {
+		attrs = append(attrs, errType)
+		span.SetAttributes(errType)
+	}
+
+	options := metric.WithAttributes(attrs...)
+
+	t.duration.Record(ctx, time.Since(start).Seconds(), options)
+
+	if request.ContentLength > 0 {
+		t.requestSize.Record(ctx, float64(request.ContentLength), options)
+	}
+
+	if err != nil {
+		t.logger.ErrorContext(ctx, "http client request", requestLogAttrs(request, slogw.Err(err))...)
+		span.End(err)
+
+		return nil, err //nolint:wrapcheck
+	}
+
+	if statusCode >= http.StatusBadRequest {
+		t.logger.WarnContext(ctx, "http client request",
+			requestLogAttrs(request, slog.Int("status", statusCode))...)
+	}
+
+	// The body of a 101 Switching Protocols response is the upgraded connection, an io.ReadWriteCloser,
+	// which is returned unwrapped, e.g. for WebSocket clients. The span ends with the upgrade.
+	if statusCode == http.StatusSwitchingProtocols {
+		span.End(nil)
+
+		return response, nil
+	}
+
+	body := &responseBody{
+		ReadCloser: response.Body,
+		ctx:        ctx,
+		span:       span,
+		size:       t.responseSize,
+		options:    options,
+		statusCode: statusCode,
+	}
+
+	if response.Body == nil || response.Body == http.NoBody {
+		body.end()
+
+		return response, nil
+	}
+
+	response.Body = body
+
+	return response, nil
+}
+
+// requestLogAttrs returns the log attributes of a request.
+func requestLogAttrs(request *http.Request, attrs ...any) []any {
+	return append([]any{
+		slog.String("method", request.Method),
+		slog.String("url", fullURL(request.URL).Value.AsString()),
+	}, attrs...)
+}
+
+// responseBody wraps a response body to record its size and end the span
+// when it is read to the end or closed.
+type responseBody struct {
+	io.ReadCloser
+
+	ctx        context.Context //nolint:containedctx
+	span       tracew.Span
+	size       metric.Float64Histogram
+	options    metric.RecordOption
+	statusCode int
+
+	once sync.Once
+	read int64
+}
+
+// Read reads from the body, ending the span at the end of the body.
+func (b *responseBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+
+	if err == io.EOF {
+		b.end()
+	}
+
+	return n, err //nolint:wrapcheck
+}
+
+// Close closes the body and ends the span.
+func (b *responseBody) Close() error {
+	err := b.ReadCloser.Close()
+
+	b.end()
+
+	return err //nolint:wrapcheck
+}
+
+// end records the response body size and ends the span once,
+// with the Error status for error responses.
+func (b *responseBody) end() {
+	b.once.Do(func() {
+		b.size.Record(b.ctx, float64(b.read), b.options)
+
+		if b.statusCode >= http.StatusBadRequest {
+			b.span.EndWithStatus(codes.Error, http.StatusText(b.statusCode))
+
+			return
+		}
+
+		b.span.End(nil)
+	})
+}
@@ -0,0 +1,254 @@
+package httpw
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yolkhovyy/go-otelw/otelw/metricw"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var propagatorOnce sync.Once
+
+// setupPropagator sets the global trace context and baggage propagator, as tracew.Configure does.
+func setupPropagator() {
+	propagatorOnce.Do(func() {
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+			propagation.TraceContext{},
+			propagation.Baggage{},
+		))
+	})
+}
+
+// newRecorder returns a tracer provider of a single test, recording the ended spans.
+func newRecorder() (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), recorder
+}
+
+// scrape returns the Prometheus text exposition of the metrics of met.
+func scrape(t *testing.T, met *metricw.Metric) string {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	met.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	return recorder.Body.String()
+}
+
+//nolint:funlen
+func TestTransport(t *testing.T) {
+	t.Parallel()
+	setupPropagator()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Header.Get("Traceparent") == "" {
+			writer.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		switch request.URL.Path {
+		case "/ok":
+			_, _ = io.WriteString(writer, "hello")
+		default:
+			http.NotFound(writer, request)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	type args struct {
+		url string
+	}
+
+	type want struct {
+		err        bool
+		statusCode int
+		body       string
+		status     codes.Code
+		errorType  string
+		logs       string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "ok",
+			args: args{url: server.URL + "/ok"},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       "hello",
+				status:     codes.Ok,
+			},
+		},
+		{
+			name: "not found",
+			args: args{url: server.URL + "/missing"},
+			want: want{
+				statusCode: http.StatusNotFound,
+				body:       "404 page not found\n",
+				status:     codes.Error,
+				errorType:  "404",
+				logs:       "level=WARN msg=\"http client request\" method=GET",
+			},
+		},
+		{
+			name: "transport error",
+			args: args{url: "http://127.0.0.1:1/unreachable"},
+			want: want{
+				err:       true,
+				status:    codes.Error,
+				errorType: "*net.OpError",
+				logs:      "level=ERROR msg=\"http client request\" method=GET",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			met, err := metricw.Configure(ctx, metricw.Config{Scrape: metricw.ScrapeConfig{Enable: true}}, nil)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, met.Shutdown(ctx))
+			}()
+
+			var logs bytes.Buffer
+
+			provider, recorder := newRecorder()
+
+			transport, err := NewTransport(TransportOptions{
+				Metric:         met,
+				Logger:         slog.New(slog.NewTextHandler(&logs, nil)),
+				TracerProvider: provider,
+			})
+			require.NoError(t, err)
+
+			ctx, parent := provider.Tracer("test").Start(ctx, "parent")
+			defer parent.End()
+
+			request, err := http.NewRequestWithContext(ctx, http.MethodGet, test.args.url, nil)
+			require.NoError(t, err)
+
+			response, err := (&http.Client{Transport: transport}).Do(request)
+			if test.want.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+
+				body, err := io.ReadAll(response.Body)
+				require.NoError(t, err)
+				require.NoError(t, response.Body.Close())
+
+				assert.Equal(t, test.want.statusCode, response.StatusCode)
+				assert.Equal(t, test.want.body, string(body))
+				assert.Contains(t, scrape(t, met), "http_client_response_body_size_bytes_sum{")
+			}
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+
+			span := spans[0]
+			assert.Equal(t, http.MethodGet, span.Name())
+			assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+			assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+			assert.Equal(t, test.want.status, span.Status().Code)
+			assert.Contains(t, span.Attributes(), semconv.HTTPRequestMethodGet)
+
+			if test.want.statusCode != 0 {
+				assert.Contains(t, span.Attributes(), semconv.HTTPResponseStatusCode(test.want.statusCode))
+			}
+
+			if test.want.errorType != "" {
+				assert.Contains(t, span.Attributes(), semconv.ErrorTypeKey.String(test.want.errorType))
+			}
+
+			assert.Contains(t, scrape(t, met), "http_client_request_duration_seconds_count{")
+
+			if test.want.logs == "" {
+				assert.Empty(t, logs.String())
+			} else {
+				assert.Contains(t, logs.String(), test.want.logs)
+			}
+		})
+	}
+}
+
+func TestTransportUpgrade(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		conn, buffer, err := http.NewResponseController(writer).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_, _ = buffer.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n")
+		_ = buffer.Flush()
+
+		// Echo the upgraded connection.
+		_, _ = io.Copy(conn, buffer)
+	}))
+	t.Cleanup(server.Close)
+
+	provider, recorder := newRecorder()
+
+	transport, err := NewTransport(TransportOptions{
+		Logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+		TracerProvider: provider,
+	})
+	require.NoError(t, err)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	defer parent.End()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	request.Header.Set("Connection", "Upgrade")
+	request.Header.Set("Upgrade", "echo")
+
+	response, err := (&http.Client{Transport: transport}).Do(request)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, response.StatusCode)
+
+	conn, ok := response.Body.(io.ReadWriteCloser)
+	require.True(t, ok)
+
+	defer conn.Close()
+
+	_, err = io.WriteString(conn, "ping")
+	require.NoError(t, err)
+
+	echo := make([]byte, 4)
+	_, err = io.ReadFull(conn, echo)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(echo))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Ok, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), semconv.HTTPResponseStatusCode(http.StatusSwitchingProtocols))
+}
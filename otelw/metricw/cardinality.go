@@ -0,0 +1,278 @@
+package metricw
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/yolkhovyy/go-otelw/otelw/slogw"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+	// cardinalityLimitEnv is the environment variable of the experimental SDK cardinality limit,
+	// read when the aggregation of an instrument is created.
+	cardinalityLimitEnv = "OTEL_GO_X_CARDINALITY_LIMIT"
+
+	// overflowKey is the attribute key of the overflow series defined by the OpenTelemetry specification.
+	overflowKey = attribute.Key("otel.metric.overflow")
+
+	// overflowLabel is the Prometheus label of the overflow series, as escaped by legacy scrapers.
+	overflowLabel = "otel_metric_overflow"
+
+	// scopeLabel is the Prometheus label of the instrumentation scope name.
+	scopeLabel = "otel_scope_name"
+
+	// overflowCounterName is the name of the internal counter of instruments exceeding the cardinality limit.
+	overflowCounterName = "otelw.metric.cardinality.overflows"
+)
+
+// cardinalityLimit is the cardinality limit set in the environment of the process,
+// shared by the Metrics configured with a limit until the last one is shut down.
+//
+//nolint:gochecknoglobals
+var cardinalityLimit struct {
+	mutex    sync.Mutex
+	limit    int
+	users    int
+	previous string
+	exists   bool
+}
+
+// setCardinalityLimit enables the SDK cardinality limit of every instrument,
+// measurements of new attribute sets beyond the limit are aggregated into
+// the otel.metric.overflow series. The limit is set with an environment variable,
+// so it applies to all meter providers of the process. A limit different from the one
+// of a Metric not shut down yet is rejected with ErrCardinalityLimitConflict.
+// It returns the function releasing the limit, the last release restores
+// the previous value of the environment variable.
+// A limit of 0 leaves the environment variable untouched.
+func setCardinalityLimit(limit int) (func() error, error) {
+	if limit <= 0 {
+		return func() error { return nil }, nil
+	}
+
+	cardinalityLimit.mutex.Lock()
+	defer cardinalityLimit.mutex.Unlock()
+
+	switch {
+	case cardinalityLimit.users == 0:
+		previous, exists := os.LookupEnv(cardinalityLimitEnv)
+
+		if err := os.Setenv(cardinalityLimitEnv, strconv.Itoa(limit)); err != nil {
+			return nil, fmt.Errorf("metricw cardinality limit: %w", err)
+		}
+
+		cardinalityLimit.limit = limit
+		cardinalityLimit.previous = previous
+		cardinalityLimit.exists = exists
+	case cardinalityLimit.limit != limit:
+		return nil, fmt.Errorf("metricw cardinality limit: %w %d, %d is set",
+			ErrCardinalityLimitConflict, limit, cardinalityLimit.limit)
+	}
+
+	cardinalityLimit.users++
+
+	var once sync.Once
+
+	return func() error {
+		var err error
+
+		once.Do(func() { err = releaseCardinalityLimit() })
+
+		return err
+	}, nil
+}
+
+// releaseCardinalityLimit releases the cardinality limit of a Metric,
+// restoring the previous value of the environment variable on the last release.
+func releaseCardinalityLimit() error {
+	cardinalityLimit.mutex.Lock()
+	defer cardinalityLimit.mutex.Unlock()
+
+	cardinalityLimit.users--
+	if cardinalityLimit.users > 0 {
+		return nil
+	}
+
+	var err error
+	if cardinalityLimit.exists {
+		err = os.Setenv(cardinalityLimitEnv, cardinalityLimit.previous)
+	} else {
+		err = os.Unsetenv(cardinalityLimitEnv)
+	}
+
+	if err != nil {
+		return fmt.Errorf("metricw cardinality limit restore: %w", err)
+	}
+
+	return nil
+}
+
+// overflowReporter reports instruments exceeding the cardinality limit.
+// The first time an instrument overflows, a warning is logged and the internal counter is incremented.
+type overflowReporter struct {
+	limit      int
+	mutex      sync.Mutex
+	overflowed map[string]struct{}
+	counter    metric.Float64Counter
+}
+
+// newOverflowReporter creates an overflowReporter of the cardinality limit.
+func newOverflowReporter(limit int) *overflowReporter {
+	return &overflowReporter{
+		limit:      limit,
+		overflowed: make(map[string]struct{}),
+	}
+}
+
+// setCounter sets the internal counter of instruments exceeding the cardinality limit.
+func (r *overflowReporter) setCounter(counter metric.Float64Counter) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.counter = counter
+}
+
+// report logs a warning and increments the internal counter the first time an instrument overflows.
+func (r *overflowReporter) report(ctx context.Context, scope, name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := scope + "/" + name
+	if _, exists := r.overflowed[key]; exists {
+		return
+	}
+
+	r.overflowed[key] = struct{}{}
+
+	slogw.DefaultLogger().WarnContext(ctx, "metric cardinality limit exceeded",
+		slog.String("scope", scope),
+		slog.String("metric", name),
+		slog.Int("limit", r.limit),
+	)
+
+	if r.counter != nil {
+		r.counter.Add(ctx, 1, metric.WithAttributes(attribute.String("metric", name)))
+	}
+}
+
+// overflowExporter is an exporter reporting the instruments exceeding the cardinality limit
+// in the exported metrics (push mode).
+type overflowExporter struct {
+	sdkmetric.Exporter
+
+	reporter *overflowReporter
+}
+
+// Export reports the instruments overflowing for the first time and exports the metrics.
+func (e *overflowExporter) Export(ctx context.Context, resourceMetrics *metricdata.ResourceMetrics) error {
+	for _, scopeMetrics := range resourceMetrics.ScopeMetrics {
+		for _, metrics := range scopeMetrics.Metrics {
+			if overflows(metrics.Data) {
+				e.reporter.report(ctx, scopeMetrics.Scope.Name, metrics.Name)
+			}
+		}
+	}
+
+	if err := e.Exporter.Export(ctx, resourceMetrics); err != nil {
+		return fmt.Errorf("metricw export: %w", err)
+	}
+
+	return nil
+}
+
+// overflowGatherer is a gatherer reporting the instruments exceeding the cardinality limit
+// in the scraped metrics (pull mode). Instruments are reported with their Prometheus names.
+type overflowGatherer struct {
+	prometheus.Gatherer
+
+	reporter *overflowReporter
+}
+
+// Gather gathers the metric families and reports the instruments overflowing for the first time.
+func (g overflowGatherer) Gather() ([]*prometheus_client.MetricFamily, error) {
+	families, err := g.Gatherer.Gather()
+
+	for _, family := range families {
+		if scope, exists := overflowScope(family); exists {
+			g.reporter.report(context.Background(), scope, family.GetName())
+		}
+	}
+
+	return families, err //nolint:wrapcheck
+}
+
+// overflowScope returns the instrumentation scope of the overflow series of a metric family,
+// or false if the family does not contain the overflow series.
+func overflowScope(family *prometheus_client.MetricFamily) (string, bool) {
+	for _, mMetric := range family.GetMetric() {
+		var (
+			scope    string
+			overflow bool
+		)
+
+		for _, label := range mMetric.GetLabel() {
+			switch label.GetName() {
+			case string(overflowKey), overflowLabel:
+				overflow = label.GetValue() == "true"
+			case scopeLabel:
+				scope = label.GetValue()
+			}
+		}
+
+		if overflow {
+			return scope, true
+		}
+	}
+
+	return "", false
+}
+
+// overflows reports whether the metric data contains the overflow series.
+func overflows(data metricdata.Aggregation) bool {
+	switch data := data.(type) {
+	case metricdata.Sum[int64]:
+		return overflowed(data.DataPoints, func(p metricdata.DataPoint[int64]) attribute.Set { return p.Attributes })
+	case metricdata.Sum[float64]:
+		return overflowed(data.DataPoints, func(p metricdata.DataPoint[float64]) attribute.Set { return p.Attributes })
+	case metricdata.Gauge[int64]:
+		return overflowed(data.DataPoints, func(p metricdata.DataPoint[int64]) attribute.Set { return p.Attributes })
+	case metricdata.Gauge[float64]:
+		return overflowed(data.DataPoints, func(p metricdata.DataPoint[float64]) attribute.Set { return p.Attributes })
+	case metricdata.Histogram[int64]:
+		return overflowed(data.DataPoints,
+			func(p metricdata.HistogramDataPoint[int64]) attribute.Set { return p.Attributes })
+	case metricdata.Histogram[float64]:
+		return overflowed(data.DataPoints,
+			func(p metricdata.HistogramDataPoint[float64]) attribute.Set { return p.Attributes })
+	case metricdata.ExponentialHistogram[int64]:
+		return overflowed(data.DataPoints,
+			func(p metricdata.ExponentialHistogramDataPoint[int64]) attribute.Set { return p.Attributes })
+	case metricdata.ExponentialHistogram[float64]:
+		return overflowed(data.DataPoints,
+			func(p metricdata.ExponentialHistogramDataPoint[float64]) attribute.Set { return p.Attributes })
+	default:
+		return false
+	}
+}
+
+// overflowed reports whether one of the data points belongs to the overflow series.
+func overflowed[P any](dataPoints []P, attributes func(P) attribute.Set) bool {
+	for _, dataPoint := range dataPoints {
+		attrs := attributes(dataPoint)
+		if value, exists := attrs.Value(overflowKey); exists && value.AsBool() {
+			return true
+		}
+	}
+
+	return false
+}
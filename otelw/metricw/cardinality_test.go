@@ -0,0 +1,115 @@
+package metricw
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+//nolint:paralleltest // The cardinality limit is set via the environment.
+func TestCardinalityLimit(t *testing.T) {
+	t.Setenv(cardinalityLimitEnv, "")
+	require.NoError(t, os.Unsetenv(cardinalityLimitEnv))
+
+	ctx := context.Background()
+
+	var buffer bytes.Buffer
+
+	met, err := Configure(ctx, Config{
+		Enable:           true,
+		Interval:         time.Hour,
+		CardinalityLimit: 3,
+	}, nil, &buffer)
+	require.NoError(t, err)
+
+	assert.Equal(t, "3", os.Getenv(cardinalityLimitEnv))
+
+	counter, err := met.Counter("test.requests", InstrumentOptions{})
+	require.NoError(t, err)
+
+	for user := range 5 {
+		counter.Add(ctx, 1, metric.WithAttributes(attribute.Int("user", user)))
+	}
+
+	require.NoError(t, met.provider.ForceFlush(ctx))
+	assert.Contains(t, buffer.String(), string(overflowKey))
+	assert.Len(t, met.overflow.overflowed, 1)
+
+	// The internal counter is exported with the next collection.
+	require.NoError(t, met.provider.ForceFlush(ctx))
+	assert.Contains(t, buffer.String(), overflowCounterName)
+	assert.Len(t, met.overflow.overflowed, 1)
+
+	// Shutdown restores the environment.
+	require.NoError(t, met.Shutdown(ctx))
+
+	_, exists := os.LookupEnv(cardinalityLimitEnv)
+	assert.False(t, exists)
+}
+
+//nolint:paralleltest // The cardinality limit is set via the environment.
+func TestCardinalityLimitScrape(t *testing.T) {
+	t.Setenv(cardinalityLimitEnv, "42")
+
+	ctx := context.Background()
+
+	met, err := Configure(ctx, Config{
+		CardinalityLimit: 3,
+		Scrape:           ScrapeConfig{Enable: true},
+	}, nil)
+	require.NoError(t, err)
+
+	counter, err := met.Counter("test.requests", InstrumentOptions{})
+	require.NoError(t, err)
+
+	for user := range 5 {
+		counter.Add(ctx, 1, metric.WithAttributes(attribute.Int("user", user)))
+	}
+
+	recorder := httptest.NewRecorder()
+	met.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Contains(t, recorder.Body.String(), `otel_metric_overflow="true"`)
+	assert.Len(t, met.overflow.overflowed, 1)
+
+	require.NoError(t, met.Shutdown(ctx))
+	assert.Equal(t, "42", os.Getenv(cardinalityLimitEnv))
+}
+
+//nolint:paralleltest // The cardinality limit is set via the environment.
+func TestCardinalityLimitConflict(t *testing.T) {
+	t.Setenv(cardinalityLimitEnv, "42")
+
+	ctx := context.Background()
+
+	first, err := Configure(ctx, Config{CardinalityLimit: 3}, nil, io.Discard)
+	require.NoError(t, err)
+
+	same, err := Configure(ctx, Config{CardinalityLimit: 3}, nil, io.Discard)
+	require.NoError(t, err)
+
+	_, err = Configure(ctx, Config{CardinalityLimit: 5}, nil, io.Discard)
+	require.ErrorIs(t, err, ErrCardinalityLimitConflict)
+
+	// The limit is kept until the last Metric configured with it is shut down, in any order.
+	require.NoError(t, first.Shutdown(ctx))
+	assert.Equal(t, "3", os.Getenv(cardinalityLimitEnv))
+
+	require.NoError(t, same.Shutdown(ctx))
+	assert.Equal(t, "42", os.Getenv(cardinalityLimitEnv))
+
+	other, err := Configure(ctx, Config{CardinalityLimit: 5}, nil, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, "5", os.Getenv(cardinalityLimitEnv))
+	require.NoError(t, other.Shutdown(ctx))
+}
@@ -1,9 +1,12 @@
 package metricw
 
 import (
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/yolkhovyy/go-otelw/otelw/otlp"
+	"github.com/yolkhovyy/go-otelw/otelw/validation"
 )
 
 // Config holds the configuration settings for the package metricw package.
@@ -14,30 +17,144 @@ type Config struct {
 	// Prometheus indicates whether Prometheus metric mapping is enabled.
 	Prometheus bool `json:"prometheus" yaml:"prometheus" mapstructure:"prometheus"`
 
+	// Runtime indicates whether the OpenTelemetry Go runtime metrics are enabled,
+	// read from runtime/metrics independently of the Prometheus mapping.
+	Runtime bool `json:"runtime" yaml:"runtime" mapstructure:"Runtime"`
+
+	// Host indicates whether the OpenTelemetry process metrics, and the system.network.io metric
+	// of the network namespace of the process, are enabled. They are read from the proc filesystem
+	// on Linux and disabled with a warning elsewhere.
+	Host bool `json:"host" yaml:"host" mapstructure:"Host"`
+
+	// PrometheusLabels filters the Prometheus label keys which are bridged as metric attributes.
+	PrometheusLabels LabelFilter `json:"prometheus_labels" yaml:"prometheusLabels" mapstructure:"PrometheusLabels"`
+
 	// Interval holds metric collection interval.
 	Interval time.Duration `json:"interval" yaml:"interval" mapstructure:"interval"`
 
 	// OTLP holds the configuration for the OTEL protocol.
 	OTLP otlp.Config `json:"otlp" yaml:"otlp" mapstructure:"OTLP"`
+
+	// Temporality of the metrics exported via OTLP: cumulative, delta or lowmemory.
+	// It applies to the SDK instruments only, the metrics bridged from Prometheus
+	// and produced by the Go runtime producer are always cumulative.
+	// The Prometheus scrape endpoint is always cumulative.
+	Temporality Temporality `json:"temporality" yaml:"temporality" mapstructure:"Temporality"`
+
+	// TemporalityOverrides overrides the temporality per instrument kind,
+	// e.g. "up_down_counter: delta". Keys are the Instrument* kind names.
+	TemporalityOverrides map[string]Temporality `json:"temporality_overrides" yaml:"temporalityOverrides" mapstructure:"TemporalityOverrides"` //nolint:lll
+
+	// ExemplarFilter selects the measurements recorded as exemplars: always_on, always_off or trace_based.
+	// Exemplars are exported via OTLP and by the scrape endpoint in the OpenMetrics format.
+	ExemplarFilter ExemplarFilter `json:"exemplar_filter" yaml:"exemplarFilter" mapstructure:"ExemplarFilter"`
+
+	// CardinalityLimit is the maximum number of attribute sets per instrument, 0 means no limit.
+	// Measurements beyond the limit are aggregated into the otel.metric.overflow series,
+	// overflowing instruments are reported when exported via OTLP or scraped.
+	// The limit is global to the process: it is set with the OTEL_GO_X_CARDINALITY_LIMIT environment
+	// variable, so it applies to all meter providers of the process, also of Metrics configured
+	// with no limit, until the last Metric configured with the limit is shut down and the previous
+	// value is restored. Configuring a different limit meanwhile fails with ErrCardinalityLimitConflict.
+	// The variable is not inherited by child processes started with tracew.InjectEnv.
+	CardinalityLimit int `json:"cardinality_limit" yaml:"cardinalityLimit" mapstructure:"CardinalityLimit"`
+
+	// Scrape holds the configuration of the Prometheus scrape endpoint.
+	Scrape ScrapeConfig `json:"scrape" yaml:"scrape" mapstructure:"Scrape"`
+
+	// Views customize the aggregation, names and attributes of the matched instruments.
+	Views []View `json:"views" yaml:"views" mapstructure:"Views"`
+}
+
+// LabelFilter holds allow and deny lists of Prometheus label keys, to control cardinality.
+// An empty allow list allows all keys, the deny list takes precedence.
+type LabelFilter struct {
+	// Allow lists the label keys to keep.
+	Allow []string `json:"allow" yaml:"allow" mapstructure:"Allow"`
+
+	// Deny lists the label keys to drop.
+	Deny []string `json:"deny" yaml:"deny" mapstructure:"Deny"`
+}
+
+// Allowed reports whether the label key passes the filter.
+func (f LabelFilter) Allowed(key string) bool {
+	if slices.Contains(f.Deny, key) {
+		return false
+	}
+
+	return len(f.Allow) == 0 || slices.Contains(f.Allow, key)
 }
 
 // Defaults returns a map of default configuration values for the metricw package.
-// It includes default settings for enabling metrics, prometheus metrics mapping,
-// metrics collection interval and defaults for the otlp package.
+// It includes default settings for enabling metrics, prometheus metrics mapping, runtime and host metrics,
+// metrics collection interval, temporality, exemplar filter, cardinality limit, the scrape endpoint and defaults for the otlp package.
 func Defaults() map[string]any {
 	defaults := make(map[string]any)
 
 	defaults["Enable"] = DefaultEnable
 	defaults["Prometheus"] = DefaultPrometheus
+	defaults["Runtime"] = DefaultRuntime
+	defaults["Host"] = DefaultHost
 	defaults["Interval"] = DefaultInterval
+	defaults["Temporality"] = DefaultTemporality
+	defaults["CardinalityLimit"] = DefaultCardinalityLimit
+	defaults["ExemplarFilter"] = DefaultExemplarFilter
 
 	for k, v := range otlp.Defaults() {
 		defaults["OTLP."+k] = v
 	}
 
+	defaults["Scrape.Enable"] = DefaultScrapeEnable
+	defaults["Scrape.Address"] = DefaultScrapeAddress
+	defaults["Scrape.Path"] = DefaultScrapePath
+
 	return defaults
 }
 
+// Validate checks the configuration and returns all problems found,
+// qualified with the configuration field paths, or nil if there are none.
+// The push configuration is validated only if metrics are enabled,
+// the scrape configuration only if the scrape endpoint is enabled,
+// the views, the exemplar filter and the cardinality limit are always validated.
+func (c Config) Validate() error {
+	return c.validate(true)
+}
+
+// validate checks the configuration, the OTLP configuration is checked only if withOTLP is true.
+func (c Config) validate(withOTLP bool) error {
+	errs := []error{validation.Join("Scrape", c.Scrape.Validate())}
+
+	if _, err := ParseExemplarFilter(string(c.ExemplarFilter)); err != nil {
+		errs = append(errs, validation.Field("ExemplarFilter", err))
+	}
+
+	if c.CardinalityLimit < 0 {
+		errs = append(errs, validation.Field("CardinalityLimit",
+			fmt.Errorf("%w %d", ErrInvalidCardinalityLimit, c.CardinalityLimit)))
+	}
+
+	for i, view := range c.Views {
+		errs = append(errs, validation.Join(fmt.Sprintf("Views[%d]", i), view.Validate()))
+	}
+
+	if !c.Enable {
+		return validation.Join("", errs...)
+	}
+
+	if c.Interval <= 0 {
+		errs = append(errs, validation.Field("Interval", fmt.Errorf("%w %s", ErrInvalidInterval, c.Interval)))
+	}
+
+	if withOTLP {
+		errs = append(errs,
+			validation.Join("OTLP", c.OTLP.Validate()),
+			c.validateTemporality(),
+		)
+	}
+
+	return validation.Join("", errs...)
+}
+
 const (
 	// DefaultEnable is the default setting for enabling metrics.
 	DefaultEnable = false
@@ -45,6 +162,21 @@ const (
 	// DefaultPrometheus is the default setting for enablingPrometheus metric mapping.
 	DefaultPrometheus = false
 
+	// DefaultRuntime is the default setting for enabling Go runtime metrics.
+	DefaultRuntime = false
+
+	// DefaultHost is the default setting for enabling process metrics.
+	DefaultHost = false
+
 	// DefaultInterval holds the default metrics collection interval.
 	DefaultInterval = 10 * time.Second
+
+	// DefaultTemporality is the default temporality of the metrics exported via OTLP.
+	DefaultTemporality = TemporalityCumulative
+
+	// DefaultExemplarFilter is the default exemplar filter.
+	DefaultExemplarFilter = ExemplarTraceBased
+
+	// DefaultCardinalityLimit is the default cardinality limit, 0 means no limit.
+	DefaultCardinalityLimit = 0
 )
@@ -16,4 +16,12 @@
 //			fmt.Fprintf(os.Stderr, "metric shutdown: %v", err)
 //		}
 //	}()
+//
+// Instruments are created and cached by name:
+//
+//	requests, err := metric.Counter("app.requests", metricw.InstrumentOptions{Unit: "{request}"})
+//	if err != nil {
+//		return err
+//	}
+//	requests.Add(ctx, 1)
 package metricw
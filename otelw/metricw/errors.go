@@ -1,11 +1,59 @@
 package metricw
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/yolkhovyy/go-otelw/otelw/otlp"
+)
 
 var (
-	// ErrInvalidProtocol is returned when config.Protocol is not equal to otlp.GRPC or otlp.HTTP.
-	ErrInvalidProtocol = errors.New("invalid protocol")
+	// ErrInvalidProtocol is returned when config.OTLP.Protocol is not equal to otlp.GRPC or otlp.HTTP.
+	// It is otlp.ErrInvalidProtocol.
+	ErrInvalidProtocol = otlp.ErrInvalidProtocol
+
+	// ErrInvalidInterval is returned when config.Interval is not positive.
+	ErrInvalidInterval = errors.New("invalid interval")
+
+	// ErrInvalidPath is returned when config.Scrape.Path does not start with a slash.
+	ErrInvalidPath = errors.New("invalid path")
 
 	// ErrInvalidMetricType is returned when a not supported Prometheus metric type is requested.
 	ErrInvalidMetricType = errors.New("invalid metric type")
+
+	// ErrInstrumentConflict is returned when an instrument name is already used by an instrument of another kind.
+	ErrInstrumentConflict = errors.New("instrument conflict")
+
+	// ErrInvalidAggregation is returned when a view aggregation is not supported.
+	ErrInvalidAggregation = errors.New("invalid aggregation")
+
+	// ErrEmptyInstrument is returned when a view does not match any instrument name.
+	ErrEmptyInstrument = errors.New("empty instrument")
+
+	// ErrInvalidView is returned when view settings contradict each other.
+	ErrInvalidView = errors.New("invalid view")
+
+	// ErrInvalidBuckets is returned when histogram bucket boundaries are not strictly increasing.
+	ErrInvalidBuckets = errors.New("invalid buckets")
+
+	// ErrInvalidTemporality is returned when config.Temporality is not cumulative, delta or lowmemory.
+	ErrInvalidTemporality = errors.New("invalid temporality")
+
+	// ErrInvalidInstrumentKind is returned when a temporality override key is not an instrument kind.
+	ErrInvalidInstrumentKind = errors.New("invalid instrument kind")
+
+	// ErrInvalidCardinalityLimit is returned when config.CardinalityLimit is negative.
+	ErrInvalidCardinalityLimit = errors.New("invalid cardinality limit")
+
+	// ErrCardinalityLimitConflict is returned when config.CardinalityLimit differs from
+	// the process-wide limit set by a Metric not shut down yet.
+	ErrCardinalityLimitConflict = errors.New("cardinality limit conflict")
+
+	// ErrUnsupportedPlatform is returned when host metrics are enabled without a proc filesystem.
+	ErrUnsupportedPlatform = errors.New("unsupported platform")
+
+	// ErrInvalidProcStat is returned when a proc filesystem entry cannot be parsed.
+	ErrInvalidProcStat = errors.New("invalid proc stat")
+
+	// ErrInvalidExemplarFilter is returned when config.ExemplarFilter is not always_on, always_off or trace_based.
+	ErrInvalidExemplarFilter = errors.New("invalid exemplar filter")
 )
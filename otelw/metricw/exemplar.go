@@ -0,0 +1,79 @@
+package metricw
+
+import (
+	"fmt"
+
+	"github.com/yolkhovyy/go-utilities/stringx"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+)
+
+// ExemplarFilter selects the measurements recorded as exemplars,
+// linking metric data points to the traces that produced them.
+type ExemplarFilter string
+
+const (
+	// ExemplarAlwaysOn records exemplars of all measurements.
+	ExemplarAlwaysOn ExemplarFilter = "always_on"
+	// ExemplarAlwaysOff records no exemplars.
+	ExemplarAlwaysOff ExemplarFilter = "always_off"
+	// ExemplarTraceBased records exemplars of measurements made within a sampled span.
+	ExemplarTraceBased ExemplarFilter = "trace_based"
+)
+
+// String returns the string representation of the ExemplarFilter.
+func (f *ExemplarFilter) String() string {
+	return string(*f)
+}
+
+// ParseExemplarFilter normalizes and validates an exemplar filter name.
+// Names are case-insensitive, surrounding spaces are ignored,
+// and an empty name is accepted as "trace_based".
+func ParseExemplarFilter(name string) (ExemplarFilter, error) {
+	switch filter := ExemplarFilter(stringx.TrimSpaceToLower(name)); filter {
+	case ExemplarAlwaysOn, ExemplarAlwaysOff, ExemplarTraceBased:
+		return filter, nil
+	case "":
+		return ExemplarTraceBased, nil
+	default:
+		return "", fmt.Errorf("parse exemplar filter: %w %s", ErrInvalidExemplarFilter, name)
+	}
+}
+
+// UnmarshalText unmarshals a text value into an ExemplarFilter, normalizing and validating it.
+// It is also used by encoding/json for JSON string values.
+func (f *ExemplarFilter) UnmarshalText(text []byte) error {
+	filter, err := ParseExemplarFilter(string(text))
+	if err != nil {
+		return fmt.Errorf("unmarshal: %w", err)
+	}
+
+	*f = filter
+
+	return nil
+}
+
+// UnmarshalYAML unmarshals a YAML value into an ExemplarFilter, validating the filter.
+// It returns an error if the filter is invalid.
+func (f *ExemplarFilter) UnmarshalYAML(unmarshal func(any) error) error {
+	var strFilter string
+	if err := unmarshal(&strFilter); err != nil {
+		return err
+	}
+
+	return f.UnmarshalText([]byte(strFilter))
+}
+
+// filter returns the SDK exemplar filter, invalid filters fall back to trace based.
+func (f ExemplarFilter) filter() exemplar.Filter {
+	filter, _ := ParseExemplarFilter(string(f))
+
+	switch filter {
+	case ExemplarAlwaysOn:
+		return exemplar.AlwaysOnFilter
+	case ExemplarAlwaysOff:
+		return exemplar.AlwaysOffFilter
+	case ExemplarTraceBased:
+	}
+
+	return exemplar.TraceBasedFilter
+}
@@ -0,0 +1,156 @@
+package metricw
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// sampledContext returns a context carrying a sampled span context.
+func sampledContext(sampled bool) context.Context {
+	var flags trace.TraceFlags
+	if sampled {
+		flags = trace.FlagsSampled
+	}
+
+	return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10},
+		SpanID:     trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+		TraceFlags: flags,
+	}))
+}
+
+//nolint:funlen
+func TestExemplarFilter(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		filter  ExemplarFilter
+		sampled bool
+	}
+
+	type want struct {
+		exemplars int
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "trace based sampled",
+			args: args{filter: ExemplarTraceBased, sampled: true},
+			want: want{exemplars: 1},
+		},
+		{
+			name: "trace based not sampled",
+			args: args{filter: ExemplarTraceBased, sampled: false},
+			want: want{exemplars: 0},
+		},
+		{
+			name: "always on",
+			args: args{filter: ExemplarAlwaysOn, sampled: false},
+			want: want{exemplars: 1},
+		},
+		{
+			name: "always off",
+			args: args{filter: ExemplarAlwaysOff, sampled: true},
+			want: want{exemplars: 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := sampledContext(test.args.sampled)
+
+			reader := sdkmetric.NewManualReader()
+			provider := sdkmetric.NewMeterProvider(
+				sdkmetric.WithReader(reader),
+				sdkmetric.WithExemplarFilter(test.args.filter.filter()),
+			)
+
+			histogram, err := provider.Meter("test").Float64Histogram("test.duration")
+			require.NoError(t, err)
+
+			histogram.Record(ctx, 1.5)
+
+			var resourceMetrics metricdata.ResourceMetrics
+
+			require.NoError(t, reader.Collect(ctx, &resourceMetrics))
+			require.Len(t, resourceMetrics.ScopeMetrics, 1)
+
+			data, ok := resourceMetrics.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64])
+			require.True(t, ok)
+			require.Len(t, data.DataPoints, 1)
+
+			exemplars := data.DataPoints[0].Exemplars
+			require.Len(t, exemplars, test.want.exemplars)
+
+			if test.want.exemplars > 0 && test.args.sampled {
+				spanContext := trace.SpanContextFromContext(ctx)
+				assert.Equal(t, spanContext.TraceID().String(), trace.TraceID(exemplars[0].TraceID).String())
+				assert.Equal(t, spanContext.SpanID().String(), trace.SpanID(exemplars[0].SpanID).String())
+			}
+		})
+	}
+}
+
+func TestScrapeExemplars(t *testing.T) {
+	t.Parallel()
+
+	ctx := sampledContext(true)
+
+	met, err := Configure(ctx, Config{
+		ExemplarFilter: ExemplarTraceBased,
+		Scrape:         ScrapeConfig{Enable: true},
+	}, nil)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, met.Shutdown(ctx))
+	}()
+
+	histogram, err := met.Histogram("test.duration", InstrumentOptions{Unit: "s", Buckets: []float64{1, 2}})
+	require.NoError(t, err)
+
+	histogram.Record(ctx, 1.5)
+
+	request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	request.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+
+	recorder := httptest.NewRecorder()
+	met.Handler().ServeHTTP(recorder, request)
+
+	body, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+
+	assert.Contains(t, recorder.Header().Get("Content-Type"), "application/openmetrics-text")
+
+	// The exemplar labels are not written in a fixed order.
+	var exemplar string
+
+	for _, line := range strings.Split(string(body), "\n") {
+		if strings.HasPrefix(line, "test_duration_seconds_bucket{") {
+			if _, labels, found := strings.Cut(line, " # "); found {
+				exemplar = labels
+			}
+		}
+	}
+
+	require.NotEmpty(t, exemplar)
+	assert.Contains(t, exemplar, `trace_id="0102030405060708090a0b0c0d0e0f10"`)
+	assert.Contains(t, exemplar, `span_id="0102030405060708"`)
+	assert.Contains(t, exemplar, `} 1.5`)
+}
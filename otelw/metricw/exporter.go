@@ -68,7 +68,7 @@ func grpcExporter( //nolint:ireturn
 	ctx context.Context,
 	config Config,
 ) (sdkmetric.Exporter, error) {
-	options := []otlpmetricgrpc.Option{}
+	options := []otlpmetricgrpc.Option{otlpmetricgrpc.WithTemporalitySelector(config.temporalitySelector())}
 	if config.OTLP.Endpoint != "" {
 		options = append(options, otlpmetricgrpc.WithEndpoint(config.OTLP.Endpoint))
 	}
@@ -98,7 +98,7 @@ func httpExporter( //nolint:ireturn
 	ctx context.Context,
 	config Config,
 ) (sdkmetric.Exporter, error) {
-	options := []otlpmetrichttp.Option{}
+	options := []otlpmetrichttp.Option{otlpmetrichttp.WithTemporalitySelector(config.temporalitySelector())}
 	if config.OTLP.Endpoint != "" {
 		options = append(options, otlpmetrichttp.WithEndpoint(config.OTLP.Endpoint))
 	}
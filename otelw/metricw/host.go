@@ -0,0 +1,300 @@
+package metricw
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// procSelf is the proc filesystem directory of the current process.
+	procSelf = "/proc/self"
+
+	// clockTicks is the number of clock ticks per second of CPU times in /proc (USER_HZ).
+	// It is assumed to be 100, the value of the architectures supported by Go,
+	// as reading sysconf(_SC_CLK_TCK) requires cgo.
+	clockTicks = 100
+
+	cpuModeKey              = attribute.Key("cpu.mode")
+	networkIODirectionKey   = attribute.Key("network.io.direction")
+	networkInterfaceNameKey = attribute.Key("network.interface.name")
+)
+
+// procStat holds the process statistics read from the proc filesystem.
+type procStat struct {
+	userTime      float64
+	systemTime    float64
+	threads       int64
+	residentBytes int64
+	virtualBytes  int64
+	fileDescs     int64
+	interfaces    []interfaceStat
+}
+
+// interfaceStat holds the statistics of a network interface read from the proc filesystem.
+type interfaceStat struct {
+	name          string
+	receiveBytes  int64
+	transmitBytes int64
+}
+
+// hostInstruments holds the observable instruments of the process metrics and of the
+// network I/O, named after the OpenTelemetry semantic conventions.
+type hostInstruments struct {
+	procDir string
+
+	cpuTime       metric.Float64ObservableCounter
+	memoryUsage   metric.Int64ObservableUpDownCounter
+	memoryVirtual metric.Int64ObservableUpDownCounter
+	fileDescCount metric.Int64ObservableUpDownCounter
+	threadCount   metric.Int64ObservableUpDownCounter
+	networkIO     metric.Int64ObservableCounter
+}
+
+// registerHost registers the process metrics read from the proc filesystem: CPU time,
+// resident and virtual memory, open file descriptors and threads. The network I/O is
+// registered as the system.network.io metric, as /proc/[pid]/net/dev holds the counters
+// of the interfaces of the network namespace, not of the process.
+// It returns ErrUnsupportedPlatform if the proc filesystem is not available.
+func (m *Metric) registerHost(procDir string) error {
+	if _, err := os.Stat(filepath.Join(procDir, "stat")); err != nil {
+		return fmt.Errorf("metricw host: %w: %w", ErrUnsupportedPlatform, err)
+	}
+
+	meter := m.provider.Meter(scopeName)
+
+	var errs [6]error
+
+	instruments := hostInstruments{procDir: procDir}
+
+	instruments.cpuTime, errs[0] = meter.Float64ObservableCounter("process.cpu.time",
+		metric.WithDescription("Total CPU seconds broken down by different CPU modes."), metric.WithUnit("s"))
+	instruments.memoryUsage, errs[1] = meter.Int64ObservableUpDownCounter("process.memory.usage",
+		metric.WithDescription("The amount of physical memory in use."), metric.WithUnit("By"))
+	instruments.memoryVirtual, errs[2] = meter.Int64ObservableUpDownCounter("process.memory.virtual",
+		metric.WithDescription("The amount of committed virtual memory."), metric.WithUnit("By"))
+	instruments.fileDescCount, errs[3] = meter.Int64ObservableUpDownCounter("process.open_file_descriptor.count",
+		metric.WithDescription("Number of file descriptors in use by the process."),
+		metric.WithUnit("{file_descriptor}"))
+	instruments.threadCount, errs[4] = meter.Int64ObservableUpDownCounter("process.thread.count",
+		metric.WithDescription("Process threads count."), metric.WithUnit("{thread}"))
+	instruments.networkIO, errs[5] = meter.Int64ObservableCounter("system.network.io",
+		metric.WithDescription("Network bytes transferred by the interfaces of the network namespace."),
+		metric.WithUnit("By"))
+
+	if err := errors.Join(errs[:]...); err != nil {
+		return fmt.Errorf("metricw host: %w", err)
+	}
+
+	registration, err := meter.RegisterCallback(instruments.observe,
+		instruments.cpuTime,
+		instruments.memoryUsage,
+		instruments.memoryVirtual,
+		instruments.fileDescCount,
+		instruments.threadCount,
+		instruments.networkIO,
+	)
+	if err != nil {
+		return fmt.Errorf("metricw host register callback: %w", err)
+	}
+
+	m.instruments.mutex.Lock()
+	defer m.instruments.mutex.Unlock()
+
+	m.registrations = append(m.registrations, registration)
+
+	return nil
+}
+
+// observe reads the process statistics and observes the host instruments.
+// Each statistic is observed if its proc entry could be read, e.g. the CPU and memory
+// metrics of a container without a net/dev entry, and the read errors are returned.
+func (i hostInstruments) observe(_ context.Context, observer metric.Observer) error {
+	stat, errs := readProcStat(i.procDir)
+
+	if errs[procStatPart] == nil {
+		observer.ObserveFloat64(i.cpuTime, stat.userTime, metric.WithAttributes(cpuModeKey.String("user")))
+		observer.ObserveFloat64(i.cpuTime, stat.systemTime, metric.WithAttributes(cpuModeKey.String("system")))
+		observer.ObserveInt64(i.threadCount, stat.threads)
+	}
+
+	if errs[procStatmPart] == nil {
+		observer.ObserveInt64(i.memoryUsage, stat.residentBytes)
+		observer.ObserveInt64(i.memoryVirtual, stat.virtualBytes)
+	}
+
+	if errs[procFDPart] == nil {
+		observer.ObserveInt64(i.fileDescCount, stat.fileDescs)
+	}
+
+	if errs[procNetDevPart] == nil {
+		for _, stat := range stat.interfaces {
+			name := networkInterfaceNameKey.String(stat.name)
+
+			observer.ObserveInt64(i.networkIO, stat.receiveBytes,
+				metric.WithAttributes(name, networkIODirectionKey.String("receive")))
+			observer.ObserveInt64(i.networkIO, stat.transmitBytes,
+				metric.WithAttributes(name, networkIODirectionKey.String("transmit")))
+		}
+	}
+
+	if err := errors.Join(errs[:]...); err != nil {
+		return fmt.Errorf("metricw host proc stat: %w", err)
+	}
+
+	return nil
+}
+
+// Parts of the process statistics, each read from its own proc entry.
+const (
+	procStatPart = iota
+	procStatmPart
+	procFDPart
+	procNetDevPart
+	procParts
+)
+
+// readProcStat reads the process statistics from the stat, statm, fd and net/dev entries of the proc directory.
+// It returns the read error of each part, indexed by procStatPart, procStatmPart, procFDPart and procNetDevPart.
+func readProcStat(procDir string) (procStat, [procParts]error) {
+	var (
+		stat procStat
+		errs [procParts]error
+	)
+
+	errs[procStatPart] = readStat(filepath.Join(procDir, "stat"), &stat)
+	errs[procStatmPart] = readStatm(filepath.Join(procDir, "statm"), &stat)
+	errs[procNetDevPart] = readNetDev(filepath.Join(procDir, "net", "dev"), &stat)
+
+	fileDescs, err := os.ReadDir(filepath.Join(procDir, "fd"))
+	if err != nil {
+		errs[procFDPart] = fmt.Errorf("read fd: %w", err)
+	}
+
+	stat.fileDescs = int64(len(fileDescs))
+
+	return stat, errs
+}
+
+// readStat reads CPU times and the number of threads from /proc/[pid]/stat.
+// The command name in parentheses may contain spaces, fields are counted after it.
+func readStat(path string, stat *procStat) error {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("read stat: %w", err)
+	}
+
+	const (
+		// Field indexes after the command name, the state is the field 3 of the stat file.
+		utimeIndex   = 14 - 3
+		stimeIndex   = 15 - 3
+		threadsIndex = 20 - 3
+	)
+
+	end := bytes.LastIndexByte(data, ')')
+	if end < 0 {
+		return fmt.Errorf("read stat: %w", ErrInvalidProcStat)
+	}
+
+	fields := strings.Fields(string(data[end+1:]))
+	if len(fields) <= threadsIndex {
+		return fmt.Errorf("read stat: %w", ErrInvalidProcStat)
+	}
+
+	utime, err1 := strconv.ParseUint(fields[utimeIndex], 10, 64)
+	stime, err2 := strconv.ParseUint(fields[stimeIndex], 10, 64)
+	threads, err3 := strconv.ParseInt(fields[threadsIndex], 10, 64)
+
+	if err := errors.Join(err1, err2, err3); err != nil {
+		return fmt.Errorf("read stat: %w", err)
+	}
+
+	stat.userTime = float64(utime) / clockTicks
+	stat.systemTime = float64(stime) / clockTicks
+	stat.threads = threads
+
+	return nil
+}
+
+// readStatm reads the virtual and resident memory sizes, in pages, from /proc/[pid]/statm.
+func readStatm(path string, stat *procStat) error {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("read statm: %w", err)
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) < 2 { //nolint:mnd
+		return fmt.Errorf("read statm: %w", ErrInvalidProcStat)
+	}
+
+	size, err1 := strconv.ParseInt(fields[0], 10, 64)
+	resident, err2 := strconv.ParseInt(fields[1], 10, 64)
+
+	if err := errors.Join(err1, err2); err != nil {
+		return fmt.Errorf("read statm: %w", err)
+	}
+
+	pageSize := int64(os.Getpagesize())
+	stat.virtualBytes = size * pageSize
+	stat.residentBytes = resident * pageSize
+
+	return nil
+}
+
+// readNetDev reads the received and transmitted bytes of the network interfaces
+// of the network namespace of the process from /proc/[pid]/net/dev.
+func readNetDev(path string, stat *procStat) error {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("read net dev: %w", err)
+	}
+	defer file.Close()
+
+	const (
+		receiveBytesIndex  = 0
+		transmitBytesIndex = 8
+	)
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		name, counters, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+
+		fields := strings.Fields(counters)
+		if len(fields) <= transmitBytesIndex {
+			continue
+		}
+
+		receive, err1 := strconv.ParseInt(fields[receiveBytesIndex], 10, 64)
+		transmit, err2 := strconv.ParseInt(fields[transmitBytesIndex], 10, 64)
+
+		if err := errors.Join(err1, err2); err != nil {
+			return fmt.Errorf("read net dev: %w", err)
+		}
+
+		stat.interfaces = append(stat.interfaces, interfaceStat{
+			name:          strings.TrimSpace(name),
+			receiveBytes:  receive,
+			transmitBytes: transmit,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read net dev: %w", err)
+	}
+
+	return nil
+}
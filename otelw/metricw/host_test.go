@@ -0,0 +1,181 @@
+package metricw
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestReadProcStat(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		procDir string
+	}
+
+	type want struct {
+		err  error
+		stat procStat
+	}
+
+	pageSize := int64(os.Getpagesize())
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "proc",
+			args: args{
+				procDir: "test_data/proc",
+			},
+			want: want{
+				stat: procStat{
+					userTime:      2.5,
+					systemTime:    0.75,
+					threads:       12,
+					residentBytes: 2048 * pageSize,
+					virtualBytes:  179200 * pageSize,
+					fileDescs:     3,
+					interfaces: []interfaceStat{
+						{name: "lo", receiveBytes: 500000, transmitBytes: 500000},
+						{name: "eth0", receiveBytes: 1000000, transmitBytes: 250000},
+						{name: "eth1", receiveBytes: 2000, transmitBytes: 1000},
+					},
+				},
+			},
+		},
+		{
+			name: "missing",
+			args: args{
+				procDir: "test_data/non-existing",
+			},
+			want: want{
+				err: os.ErrNotExist,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			stat, errs := readProcStat(test.args.procDir)
+
+			err := errors.Join(errs[:]...)
+			if test.want.err != nil {
+				require.ErrorIs(t, err, test.want.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want.stat, stat)
+		})
+	}
+}
+
+func TestHostAndRuntimeMetrics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	reader := sdkmetric.NewManualReader(sdkmetric.WithProducer(newRuntimeProducer()))
+	met := &Metric{provider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))}
+
+	require.NoError(t, met.registerRuntime())
+	require.NoError(t, met.registerHost("test_data/proc"))
+	require.ErrorIs(t, met.registerHost("test_data/non-existing"), ErrUnsupportedPlatform)
+
+	var resourceMetrics metricdata.ResourceMetrics
+
+	require.NoError(t, reader.Collect(ctx, &resourceMetrics))
+
+	data := make(map[string]metricdata.Aggregation)
+
+	for _, scopeMetrics := range resourceMetrics.ScopeMetrics {
+		assert.Equal(t, scopeName, scopeMetrics.Scope.Name)
+
+		for _, metrics := range scopeMetrics.Metrics {
+			data[metrics.Name] = metrics.Data
+		}
+	}
+
+	for _, name := range []string{
+		"go.memory.used",
+		"go.memory.allocated",
+		"go.memory.allocations",
+		"go.memory.gc.goal",
+		"go.goroutine.count",
+		"go.processor.limit",
+		"go.config.gogc",
+		goScheduleDurationName,
+		"process.cpu.time",
+		"process.memory.usage",
+		"process.memory.virtual",
+		"process.open_file_descriptor.count",
+		"process.thread.count",
+		"system.network.io",
+	} {
+		assert.Contains(t, data, name)
+	}
+
+	goroutines, ok := data["go.goroutine.count"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, goroutines.DataPoints, 1)
+	assert.Positive(t, goroutines.DataPoints[0].Value)
+
+	schedule, ok := data[goScheduleDurationName].(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, schedule.DataPoints, 1)
+	assert.Len(t, schedule.DataPoints[0].BucketCounts, len(schedule.DataPoints[0].Bounds)+1)
+
+	threads, ok := data["process.thread.count"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, threads.DataPoints, 1)
+	assert.Equal(t, int64(12), threads.DataPoints[0].Value)
+
+	network, ok := data["system.network.io"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	assert.Len(t, network.DataPoints, 6)
+
+	require.NoError(t, met.Shutdown(ctx))
+}
+
+func TestHostPartialProcStat(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	reader := sdkmetric.NewManualReader()
+	met := &Metric{provider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))}
+
+	// The proc directory has no fd and net/dev entries.
+	require.NoError(t, met.registerHost("test_data/proc_partial"))
+
+	var resourceMetrics metricdata.ResourceMetrics
+
+	require.ErrorIs(t, reader.Collect(ctx, &resourceMetrics), os.ErrNotExist)
+	require.Len(t, resourceMetrics.ScopeMetrics, 1)
+
+	var names []string
+	for _, metrics := range resourceMetrics.ScopeMetrics[0].Metrics {
+		names = append(names, metrics.Name)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"process.cpu.time",
+		"process.memory.usage",
+		"process.memory.virtual",
+		"process.thread.count",
+	}, names)
+
+	require.NoError(t, met.Shutdown(ctx))
+}
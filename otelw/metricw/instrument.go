@@ -0,0 +1,223 @@
+package metricw
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+)
+
+// InstrumentOptions holds the options of an instrument created by the Metric accessors.
+// Options are applied when an instrument is created, repeated names return the
+// cached instrument regardless of the options.
+type InstrumentOptions struct {
+	// Description of the instrument.
+	Description string
+
+	// Unit of the instrument, in UCUM notation e.g. "s", "By" or "{request}".
+	Unit string
+
+	// Buckets are the explicit bucket boundaries of a histogram, ignored by other instruments.
+	// If empty, the default boundaries of the SDK or of a matching view are used.
+	Buckets []float64
+}
+
+// instrumentKind identifies the kind of a cached instrument.
+type instrumentKind string
+
+const (
+	kindCounter                 instrumentKind = "counter"
+	kindUpDownCounter           instrumentKind = "up-down counter"
+	kindHistogram               instrumentKind = "histogram"
+	kindGauge                   instrumentKind = "gauge"
+	kindObservableCounter       instrumentKind = "observable counter"
+	kindObservableUpDownCounter instrumentKind = "observable up-down counter"
+	kindObservableGauge         instrumentKind = "observable gauge"
+)
+
+// instrument is a cached instrument with its kind.
+type instrument struct {
+	kind  instrumentKind
+	value any
+}
+
+// instruments caches the instruments created by the Metric accessors by name.
+type instruments struct {
+	mutex  sync.Mutex
+	byName map[string]instrument
+}
+
+// Counter returns the float64 counter with the given name, creating it on first use.
+func (m *Metric) Counter(name string, options InstrumentOptions) (metric.Float64Counter, error) { //nolint:ireturn
+	return cached(m, name, kindCounter, func() (metric.Float64Counter, error) {
+		return m.meter.Float64Counter(name,
+			metric.WithDescription(options.Description),
+			metric.WithUnit(options.Unit),
+		)
+	})
+}
+
+// UpDownCounter returns the float64 up-down counter with the given name, creating it on first use.
+func (m *Metric) UpDownCounter(name string, options InstrumentOptions) (metric.Float64UpDownCounter, error) { //nolint:ireturn,lll
+	return cached(m, name, kindUpDownCounter, func() (metric.Float64UpDownCounter, error) {
+		return m.meter.Float64UpDownCounter(name,
+			metric.WithDescription(options.Description),
+			metric.WithUnit(options.Unit),
+		)
+	})
+}
+
+// Histogram returns the float64 histogram with the given name, creating it on first use.
+func (m *Metric) Histogram(name string, options InstrumentOptions) (metric.Float64Histogram, error) { //nolint:ireturn
+	return cached(m, name, kindHistogram, func() (metric.Float64Histogram, error) {
+		histogramOptions := []metric.Float64HistogramOption{
+			metric.WithDescription(options.Description),
+			metric.WithUnit(options.Unit),
+		}
+
+		if len(options.Buckets) > 0 {
+			histogramOptions = append(histogramOptions, metric.WithExplicitBucketBoundaries(options.Buckets...))
+		}
+
+		return m.meter.Float64Histogram(name, histogramOptions...)
+	})
+}
+
+// Gauge returns the float64 gauge with the given name, creating it on first use.
+func (m *Metric) Gauge(name string, options InstrumentOptions) (metric.Float64Gauge, error) { //nolint:ireturn
+	return cached(m, name, kindGauge, func() (metric.Float64Gauge, error) {
+		return m.meter.Float64Gauge(name,
+			metric.WithDescription(options.Description),
+			metric.WithUnit(options.Unit),
+		)
+	})
+}
+
+// ObservableCounter returns the float64 observable counter with the given name, creating it on first use.
+// The callback, if not nil, is registered when the instrument is created and unregistered on Shutdown,
+// the callbacks of repeated names are ignored.
+func (m *Metric) ObservableCounter( //nolint:ireturn
+	name string,
+	options InstrumentOptions,
+	callback metric.Float64Callback,
+) (metric.Float64ObservableCounter, error) {
+	return cached(m, name, kindObservableCounter, func() (metric.Float64ObservableCounter, error) {
+		counter, err := m.meter.Float64ObservableCounter(name,
+			metric.WithDescription(options.Description),
+			metric.WithUnit(options.Unit),
+		)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		return counter, m.registerCallback(counter, callback)
+	})
+}
+
+// ObservableUpDownCounter returns the float64 observable up-down counter with the given name,
+// creating it on first use. The callback, if not nil, is registered when the instrument is created
+// and unregistered on Shutdown, the callbacks of repeated names are ignored.
+func (m *Metric) ObservableUpDownCounter( //nolint:ireturn
+	name string,
+	options InstrumentOptions,
+	callback metric.Float64Callback,
+) (metric.Float64ObservableUpDownCounter, error) {
+	return cached(m, name, kindObservableUpDownCounter, func() (metric.Float64ObservableUpDownCounter, error) {
+		counter, err := m.meter.Float64ObservableUpDownCounter(name,
+			metric.WithDescription(options.Description),
+			metric.WithUnit(options.Unit),
+		)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		return counter, m.registerCallback(counter, callback)
+	})
+}
+
+// ObservableGauge returns the float64 observable gauge with the given name, creating it on first use.
+// The callback, if not nil, is registered when the instrument is created and unregistered on Shutdown,
+// the callbacks of repeated names are ignored.
+func (m *Metric) ObservableGauge( //nolint:ireturn
+	name string,
+	options InstrumentOptions,
+	callback metric.Float64Callback,
+) (metric.Float64ObservableGauge, error) {
+	return cached(m, name, kindObservableGauge, func() (metric.Float64ObservableGauge, error) {
+		gauge, err := m.meter.Float64ObservableGauge(name,
+			metric.WithDescription(options.Description),
+			metric.WithUnit(options.Unit),
+		)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		return gauge, m.registerCallback(gauge, callback)
+	})
+}
+
+// cached returns the instrument cached under the given name, or creates and caches it.
+// It returns ErrInstrumentConflict if the name is already used by an instrument of another kind.
+func cached[T any](m *Metric, name string, kind instrumentKind, create func() (T, error)) (T, error) { //nolint:ireturn
+	m.instruments.mutex.Lock()
+	defer m.instruments.mutex.Unlock()
+
+	var zero T
+
+	if cached, exists := m.instruments.byName[name]; exists {
+		if cached.kind != kind {
+			return zero, fmt.Errorf("metricw %s %q: %w with %s", kind, name, ErrInstrumentConflict, cached.kind)
+		}
+
+		value, _ := cached.value.(T)
+
+		return value, nil
+	}
+
+	value, err := create()
+	if err != nil {
+		return zero, fmt.Errorf("metricw %s %q: %w", kind, name, err)
+	}
+
+	if m.instruments.byName == nil {
+		m.instruments.byName = make(map[string]instrument)
+	}
+
+	m.instruments.byName[name] = instrument{kind: kind, value: value}
+
+	return value, nil
+}
+
+// registerCallback registers the callback observing the instrument, the registration
+// is kept to be unregistered on Shutdown. It is called by cached, with the instruments mutex held.
+func (m *Metric) registerCallback(observable metric.Float64Observable, callback metric.Float64Callback) error {
+	if callback == nil {
+		return nil
+	}
+
+	registration, err := m.meter.RegisterCallback(func(ctx context.Context, observer metric.Observer) error {
+		return callback(ctx, float64Observer{observer: observer, observable: observable})
+	}, observable)
+	if err != nil {
+		return fmt.Errorf("register callback: %w", err)
+	}
+
+	m.registrations = append(m.registrations, registration)
+
+	return nil
+}
+
+// float64Observer observes values of a single observable instrument within a registered callback.
+type float64Observer struct {
+	embedded.Float64Observer
+
+	observer   metric.Observer
+	observable metric.Float64Observable
+}
+
+// Observe records the value of the observable instrument.
+func (o float64Observer) Observe(value float64, options ...metric.ObserveOption) {
+	o.observer.ObserveFloat64(o.observable, value, options...)
+}
@@ -0,0 +1,224 @@
+package metricw
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+//nolint:funlen
+func TestInstruments(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		create func(met *Metric) error
+		name   string
+	}
+
+	type want struct {
+		err  error
+		data metricdata.Aggregation
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "counter repeated",
+			args: args{
+				create: func(met *Metric) error {
+					for range 3 {
+						counter, err := met.Counter("test.requests", InstrumentOptions{Unit: "{request}"})
+						if err != nil {
+							return err
+						}
+
+						counter.Add(context.Background(), 1)
+					}
+
+					return nil
+				},
+				name: "test.requests",
+			},
+			want: want{
+				data: metricdata.Sum[float64]{
+					DataPoints:  []metricdata.DataPoint[float64]{{Value: 3}},
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+				},
+			},
+		},
+		{
+			name: "histogram buckets",
+			args: args{
+				create: func(met *Metric) error {
+					histogram, err := met.Histogram("test.duration", InstrumentOptions{Unit: "s", Buckets: []float64{1, 2}})
+					if err != nil {
+						return err
+					}
+
+					histogram.Record(context.Background(), 1.5)
+
+					return nil
+				},
+				name: "test.duration",
+			},
+			want: want{
+				data: metricdata.Histogram[float64]{
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						Count:        1,
+						Sum:          1.5,
+						Bounds:       []float64{1, 2},
+						BucketCounts: []uint64{0, 1, 0},
+						Min:          metricdata.NewExtrema(1.5),
+						Max:          metricdata.NewExtrema(1.5),
+					}},
+					Temporality: metricdata.CumulativeTemporality,
+				},
+			},
+		},
+		{
+			name: "observable gauge",
+			args: args{
+				create: func(met *Metric) error {
+					_, err := met.ObservableGauge("test.temperature", InstrumentOptions{},
+						func(_ context.Context, observer metric.Float64Observer) error {
+							observer.Observe(21.5)
+
+							return nil
+						})
+
+					return err
+				},
+				name: "test.temperature",
+			},
+			want: want{
+				data: metricdata.Gauge[float64]{
+					DataPoints: []metricdata.DataPoint[float64]{{Value: 21.5}},
+				},
+			},
+		},
+		{
+			name: "observable gauge repeated",
+			args: args{
+				create: func(met *Metric) error {
+					var calls float64
+
+					for range 3 {
+						_, err := met.ObservableGauge("test.calls", InstrumentOptions{},
+							func(_ context.Context, observer metric.Float64Observer) error {
+								calls++
+								observer.Observe(calls)
+
+								return nil
+							})
+						if err != nil {
+							return err
+						}
+					}
+
+					return nil
+				},
+				name: "test.calls",
+			},
+			want: want{
+				data: metricdata.Gauge[float64]{
+					DataPoints: []metricdata.DataPoint[float64]{{Value: 1}},
+				},
+			},
+		},
+		{
+			name: "conflicting kinds",
+			args: args{
+				create: func(met *Metric) error {
+					if _, err := met.Counter("test.conflict", InstrumentOptions{}); err != nil {
+						return err
+					}
+
+					_, err := met.Gauge("test.conflict", InstrumentOptions{})
+
+					return err
+				},
+			},
+			want: want{
+				err: ErrInstrumentConflict,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			met, reader := newTestMetric(LabelFilter{})
+
+			err := test.args.create(met)
+			if test.want.err != nil {
+				require.ErrorIs(t, err, test.want.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			var resourceMetrics metricdata.ResourceMetrics
+
+			require.NoError(t, reader.Collect(ctx, &resourceMetrics))
+
+			var data metricdata.Aggregation
+
+			for _, scopeMetrics := range resourceMetrics.ScopeMetrics {
+				for _, metrics := range scopeMetrics.Metrics {
+					if metrics.Name == test.args.name {
+						data = metrics.Data
+					}
+				}
+			}
+
+			require.NotNil(t, data)
+			metricdatatest.AssertAggregationsEqual(t, test.want.data, data, metricdatatest.IgnoreTimestamp())
+			require.NoError(t, met.Shutdown(ctx))
+		})
+	}
+}
+
+func TestInstrumentsConcurrent(t *testing.T) {
+	t.Parallel()
+
+	met, _ := newTestMetric(LabelFilter{})
+
+	const goroutines = 8
+
+	counters := make([]metric.Float64Counter, goroutines)
+
+	var waitGroup sync.WaitGroup
+
+	for i := range goroutines {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			counter, err := met.Counter("test.concurrent", InstrumentOptions{})
+			assert.NoError(t, err)
+
+			counters[i] = counter
+		}()
+	}
+
+	waitGroup.Wait()
+
+	for i := range counters {
+		assert.Same(t, counters[0], counters[i])
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/yolkhovyy/go-otelw/otelw/slogw"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -24,12 +26,16 @@ type Metric struct {
 	exporter      sdkmetric.Exporter
 	registrations []metric.Registration
 	meter         metric.Meter
+	instruments   instruments
 
-	counters   map[string]metric.Float64ObservableCounter
-	gauges     map[string]metric.Float64ObservableGauge
-	histograms map[string]metric.Float64Histogram
-
+	prometheusMutex    sync.Mutex
 	prometheusRegistry *prometheus.Registry
+	prometheusProducer *prometheusProducer
+
+	scrape *scrape
+
+	overflow                *overflowReporter
+	restoreCardinalityLimit func() error
 }
 
 // Configure initializes and configures the OpenTelemetry metric provider.
@@ -41,8 +47,7 @@ func Configure( //nolint:cyclop,funlen
 	attrs []attribute.KeyValue,
 	writers ...io.Writer,
 ) (*Metric, error) {
-	exporter, err := exporter(ctx, config, writers...)
-	if err != nil {
+	if err := config.validate(len(writers) == 0); err != nil {
 		return nil, fmt.Errorf("metricw configure: %w", err)
 	}
 
@@ -51,14 +56,59 @@ func Configure( //nolint:cyclop,funlen
 		return nil, fmt.Errorf("metricw configure resource merge: %w", err)
 	}
 
-	reader := sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(config.Interval))
+	exporter, err := exporter(ctx, config, writers...)
+	if err != nil {
+		return nil, fmt.Errorf("metricw configure: %w", err)
+	}
+
+	var overflow *overflowReporter
+
+	if config.CardinalityLimit > 0 {
+		overflow = newOverflowReporter(config.CardinalityLimit)
+		exporter = &overflowExporter{Exporter: exporter, reporter: overflow}
+	}
 
-	provider := sdkmetric.NewMeterProvider(
+	options := []sdkmetric.Option{
 		sdkmetric.WithResource(res),
-		sdkmetric.WithReader(reader),
-	)
+		sdkmetric.WithView(views(config.Views)...),
+		sdkmetric.WithExemplarFilter(config.ExemplarFilter.filter()),
+	}
 
-	otel.SetMeterProvider(provider)
+	producer := newPrometheusProducer(config.PrometheusLabels)
+	producers := []sdkmetric.Producer{producer}
+
+	if config.Runtime {
+		producers = append(producers, newRuntimeProducer())
+	}
+
+	// The periodic reader is omitted only in pull mode without push.
+	if config.Enable || !config.Scrape.Enable {
+		readerOptions := []sdkmetric.PeriodicReaderOption{sdkmetric.WithInterval(config.Interval)}
+		for _, producer := range producers {
+			readerOptions = append(readerOptions, sdkmetric.WithProducer(producer))
+		}
+
+		reader := sdkmetric.NewPeriodicReader(exporter, readerOptions...)
+		options = append(options, sdkmetric.WithReader(reader))
+	}
+
+	var scrape *scrape
+
+	if config.Scrape.Enable {
+		scrape, err = newScrape(overflow, producers...)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("metricw configure scrape: %w", err), exporter.Shutdown(ctx))
+		}
+
+		options = append(options, scrape.option())
+	}
+
+	restoreCardinalityLimit, err := setCardinalityLimit(config.CardinalityLimit)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("metricw configure: %w", err), exporter.Shutdown(ctx))
+	}
+
+	provider := sdkmetric.NewMeterProvider(options...)
 
 	serviceName := "undefined"
 
@@ -71,10 +121,39 @@ func Configure( //nolint:cyclop,funlen
 		provider:      provider,
 		exporter:      exporter,
 		registrations: make([]metric.Registration, 0),
-		gauges:        make(map[string]metric.Float64ObservableGauge),
-		histograms:    make(map[string]metric.Float64Histogram),
-		counters:      make(map[string]metric.Float64ObservableCounter),
 		meter:         provider.Meter(serviceName),
+		scrape:        scrape,
+
+		prometheusProducer: producer,
+
+		overflow:                overflow,
+		restoreCardinalityLimit: restoreCardinalityLimit,
+	}
+
+	if overflow != nil {
+		counter, err := met.Counter(overflowCounterName, InstrumentOptions{
+			Description: "Instruments exceeding the cardinality limit.",
+			Unit:        "{instrument}",
+		})
+		if err != nil {
+			return nil, met.shutdownOnError(ctx, fmt.Errorf("metricw configure overflow counter: %w", err))
+		}
+
+		overflow.setCounter(counter)
+	}
+
+	if config.Runtime {
+		if err = met.registerRuntime(); err != nil {
+			return nil, met.shutdownOnError(ctx, fmt.Errorf("metricw configure: %w", err))
+		}
+	}
+
+	if config.Host {
+		if err = met.registerHost(procSelf); errors.Is(err, ErrUnsupportedPlatform) {
+			slogw.DefaultLogger().WarnContext(ctx, "metricw host metrics disabled", slogw.Err(err))
+		} else if err != nil {
+			return nil, met.shutdownOnError(ctx, fmt.Errorf("metricw configure: %w", err))
+		}
 	}
 
 	if config.Prometheus {
@@ -82,32 +161,65 @@ func Configure( //nolint:cyclop,funlen
 			collectors.NewGoCollector(),
 			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
 		); err != nil {
-			return nil, fmt.Errorf("metricw configure prometheus collectors: %w", err)
+			return nil, met.shutdownOnError(ctx, fmt.Errorf("metricw configure prometheus collectors: %w", err))
+		}
+	}
+
+	// The scrape endpoint is served last, so that no listener is left behind on configuration errors.
+	if scrape != nil {
+		if err = scrape.serve(ctx, config.Scrape); err != nil {
+			return nil, met.shutdownOnError(ctx, fmt.Errorf("metricw configure scrape: %w", err))
 		}
 	}
 
+	otel.SetMeterProvider(provider)
+
 	return &met, nil
 }
 
+// shutdownOnError shuts down the components configured before a configuration error,
+// and returns the error joined with the shutdown errors.
+func (m *Metric) shutdownOnError(ctx context.Context, err error) error {
+	return errors.Join(err, m.Shutdown(ctx))
+}
+
 // Shutdown gracefully shuts down all metric-related components.
 // It unregisters metric registrations, shuts down the provider,
 // and ensures the exporter is properly closed.
 func (m *Metric) Shutdown(ctx context.Context) error {
 	var errs error
 
-	for i := range m.registrations {
-		err := m.registrations[i].Unregister()
-		errs = errors.Join(errs, fmt.Errorf("metricw registration shutdown: %w", err))
+	m.instruments.mutex.Lock()
+	registrations := m.registrations
+	m.registrations = nil
+	m.instruments.mutex.Unlock()
+
+	for i := range registrations {
+		if err := registrations[i].Unregister(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("metricw registration shutdown: %w", err))
+		}
+	}
+
+	if m.scrape != nil {
+		if err := m.scrape.shutdown(ctx); err != nil {
+			errs = errors.Join(errs, err)
+		}
 	}
 
 	if m.provider != nil {
-		err := m.provider.Shutdown(ctx)
-		errs = errors.Join(errs, fmt.Errorf("metricw provider shutdown: %w", err))
+		if err := m.provider.Shutdown(ctx); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("metricw provider shutdown: %w", err))
+		}
 	}
 
 	if m.exporter != nil {
-		err := m.exporter.Shutdown(ctx)
-		errs = errors.Join(errs, fmt.Errorf("metricw exporter shutdown: %w", err))
+		if err := m.exporter.Shutdown(ctx); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("metricw exporter shutdown: %w", err))
+		}
+	}
+
+	if m.restoreCardinalityLimit != nil {
+		errs = errors.Join(errs, m.restoreCardinalityLimit())
 	}
 
 	return errs
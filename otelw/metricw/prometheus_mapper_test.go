@@ -0,0 +1,325 @@
+package metricw
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/protobuf/proto"
+)
+
+var update = flag.Bool("update", false, "update golden files") //nolint:gochecknoglobals
+
+//nolint:funlen
+func TestPrometheusBridge(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		labels LabelFilter
+		split  bool
+	}
+
+	type want struct {
+		golden string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "all labels",
+			args: args{},
+			want: want{
+				golden: "test_data/prometheus_bridge.golden.json",
+			},
+		},
+		{
+			name: "filtered labels",
+			args: args{
+				labels: LabelFilter{
+					Deny: []string{"method"},
+				},
+			},
+			want: want{
+				golden: "test_data/prometheus_bridge_filtered.golden.json",
+			},
+		},
+		{
+			name: "multiple gatherers",
+			args: args{
+				split: true,
+			},
+			want: want{
+				golden: "test_data/prometheus_bridge.golden.json",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			met, reader := newTestMetric(test.args.labels)
+
+			fixture := prometheusFixture()
+
+			if test.args.split {
+				// Collectors registered in several calls and on an external registry are all bridged.
+				err := met.RegisterPrometheusCollectors(ctx, fixture[0])
+				require.NoError(t, err)
+
+				err = met.RegisterPrometheusCollectors(ctx, fixture[1])
+				require.NoError(t, err)
+
+				registry := prometheus.NewRegistry()
+				registry.MustRegister(fixture[2:]...)
+				met.RegisterPrometheusGatherers(registry)
+			} else {
+				err := met.RegisterPrometheusCollectors(ctx, fixture...)
+				require.NoError(t, err)
+			}
+
+			var resourceMetrics metricdata.ResourceMetrics
+
+			err := reader.Collect(ctx, &resourceMetrics)
+			require.NoError(t, err)
+
+			actual, err := json.MarshalIndent(normalize(resourceMetrics.ScopeMetrics), "", "  ")
+			require.NoError(t, err)
+
+			if *update {
+				err = os.WriteFile(filepath.Clean(test.want.golden), actual, 0o600)
+				require.NoError(t, err)
+			}
+
+			expected, err := os.ReadFile(filepath.Clean(test.want.golden))
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expected), string(actual))
+		})
+	}
+}
+
+//nolint:funlen
+func TestLabelFilter(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		filter LabelFilter
+	}
+
+	type want struct {
+		attrs []attribute.KeyValue
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "empty filter",
+			args: args{},
+			want: want{
+				attrs: []attribute.KeyValue{
+					attribute.String("code", "200"),
+					attribute.String("method", "GET"),
+					attribute.String("path", "/users"),
+				},
+			},
+		},
+		{
+			name: "allow",
+			args: args{
+				filter: LabelFilter{Allow: []string{"code", "method"}},
+			},
+			want: want{
+				attrs: []attribute.KeyValue{
+					attribute.String("code", "200"),
+					attribute.String("method", "GET"),
+				},
+			},
+		},
+		{
+			name: "deny",
+			args: args{
+				filter: LabelFilter{Deny: []string{"path"}},
+			},
+			want: want{
+				attrs: []attribute.KeyValue{
+					attribute.String("code", "200"),
+					attribute.String("method", "GET"),
+				},
+			},
+		},
+		{
+			name: "allow and deny",
+			args: args{
+				filter: LabelFilter{Allow: []string{"code", "method"}, Deny: []string{"method"}},
+			},
+			want: want{
+				attrs: []attribute.KeyValue{
+					attribute.String("code", "200"),
+				},
+			},
+		},
+		{
+			name: "deny all",
+			args: args{
+				filter: LabelFilter{Deny: []string{"code", "method", "path"}},
+			},
+			want: want{},
+		},
+	}
+
+	labels := []*prometheus_client.LabelPair{
+		{Name: proto.String("method"), Value: proto.String("GET")},
+		{Name: proto.String("code"), Value: proto.String("200")},
+		{Name: proto.String("path"), Value: proto.String("/users")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			set := labelSet(test.args.filter, labels)
+			assert.Equal(t, test.want.attrs, set.ToSlice())
+
+			for _, label := range labels {
+				_, exists := set.Value(attribute.Key(label.GetName()))
+				assert.Equal(t, exists, test.args.filter.Allowed(label.GetName()), label.GetName())
+			}
+		})
+	}
+}
+
+// newTestMetric creates a Metric collected by a manual reader.
+func newTestMetric(labels LabelFilter) (*Metric, *sdkmetric.ManualReader) {
+	producer := newPrometheusProducer(labels)
+	reader := sdkmetric.NewManualReader(sdkmetric.WithProducer(producer))
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	return &Metric{
+		provider:           provider,
+		registrations:      make([]metric.Registration, 0),
+		meter:              provider.Meter("test"),
+		prometheusProducer: producer,
+	}, reader
+}
+
+// prometheusFixture returns Prometheus collectors with deterministic values.
+func prometheusFixture() []prometheus.Collector {
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "test_requests_total",
+		Help: "Test requests.",
+	}, []string{"method", "code"})
+	counter.WithLabelValues("GET", "200").Add(3)
+	counter.WithLabelValues("POST", "500").Add(1)
+
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "test_temperature",
+		Help: "Test temperature.",
+	}, []string{"method"})
+	gauge.WithLabelValues("GET").Set(21.5)
+	gauge.WithLabelValues("POST").Set(-3)
+
+	histogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "test_duration_seconds",
+		Help:    "Test duration.",
+		Buckets: []float64{0.1, 0.5, 1},
+	}, []string{"method"})
+
+	for _, value := range []float64{0.05, 0.2, 0.3, 0.7, 2} {
+		histogram.WithLabelValues("GET").Observe(value)
+	}
+
+	for _, value := range []float64{0.4, 0.9} {
+		histogram.WithLabelValues("POST").Observe(value)
+	}
+
+	summary := prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Name:       "test_size_bytes",
+		Help:       "Test size.",
+		Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01},
+	}, []string{"method"})
+
+	for value := 1; value <= 10; value++ {
+		summary.WithLabelValues("GET").Observe(float64(value))
+	}
+
+	summary.WithLabelValues("POST").Observe(100)
+
+	return []prometheus.Collector{counter, gauge, histogram, summary}
+}
+
+// normalize zeroes the timestamps, sorts the metrics by name and the data points by attributes,
+// so that the collected metrics can be compared with golden files.
+func normalize(scopeMetrics []metricdata.ScopeMetrics) []metricdata.ScopeMetrics {
+	for i := range scopeMetrics {
+		metrics := scopeMetrics[i].Metrics
+		sort.Slice(metrics, func(a, b int) bool { return metrics[a].Name < metrics[b].Name })
+
+		for j := range metrics {
+			switch data := metrics[j].Data.(type) {
+			case metricdata.Gauge[float64]:
+				sort.Slice(data.DataPoints, func(a, b int) bool {
+					return encoded(data.DataPoints[a].Attributes) < encoded(data.DataPoints[b].Attributes)
+				})
+
+				for k := range data.DataPoints {
+					data.DataPoints[k].StartTime, data.DataPoints[k].Time = time.Time{}, time.Time{}
+				}
+			case metricdata.Sum[float64]:
+				sort.Slice(data.DataPoints, func(a, b int) bool {
+					return encoded(data.DataPoints[a].Attributes) < encoded(data.DataPoints[b].Attributes)
+				})
+
+				for k := range data.DataPoints {
+					data.DataPoints[k].StartTime, data.DataPoints[k].Time = time.Time{}, time.Time{}
+				}
+			case metricdata.Histogram[float64]:
+				sort.Slice(data.DataPoints, func(a, b int) bool {
+					return encoded(data.DataPoints[a].Attributes) < encoded(data.DataPoints[b].Attributes)
+				})
+
+				for k := range data.DataPoints {
+					data.DataPoints[k].StartTime, data.DataPoints[k].Time = time.Time{}, time.Time{}
+				}
+			case metricdata.Summary:
+				sort.Slice(data.DataPoints, func(a, b int) bool {
+					return encoded(data.DataPoints[a].Attributes) < encoded(data.DataPoints[b].Attributes)
+				})
+
+				for k := range data.DataPoints {
+					data.DataPoints[k].StartTime, data.DataPoints[k].Time = time.Time{}, time.Time{}
+				}
+			}
+		}
+	}
+
+	sort.Slice(scopeMetrics, func(a, b int) bool { return scopeMetrics[a].Scope.Name < scopeMetrics[b].Scope.Name })
+
+	return scopeMetrics
+}
+
+// encoded returns the encoded attribute set, used as the sort key of data points.
+func encoded(set attribute.Set) string {
+	return set.Encoded(attribute.DefaultEncoder())
+}
@@ -0,0 +1,338 @@
+package metricw
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/yolkhovyy/go-otelw/otelw/slogw"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// scopeName is the instrumentation scope of the metrics bridged or produced by metricw.
+const scopeName = "github.com/yolkhovyy/go-otelw/otelw/metricw"
+
+// prometheusProducer is an sdkmetric.Producer bridging Prometheus gatherers into the
+// MeterProvider. Counters are exported as cumulative sums, gauges and untyped metrics
+// as gauges, histograms and summaries with their original buckets and quantiles.
+// It is registered on the readers when the MeterProvider is built, and produces
+// nothing until gatherers are added. Prometheus metrics are cumulative, so the bridged
+// sums and histograms are cumulative regardless of the configured temporality.
+type prometheusProducer struct {
+	mutex     sync.RWMutex
+	gatherers prometheus.Gatherers
+	labels    LabelFilter
+	start     time.Time
+}
+
+// newPrometheusProducer creates a prometheusProducer using the given label filter.
+func newPrometheusProducer(labels LabelFilter) *prometheusProducer {
+	return &prometheusProducer{
+		labels: labels,
+		start:  time.Now(),
+	}
+}
+
+// addGatherers adds gatherers of Prometheus metric families.
+func (p *prometheusProducer) addGatherers(gatherers ...prometheus.Gatherer) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.gatherers = append(p.gatherers, gatherers...)
+}
+
+// Produce gathers the Prometheus metric families and converts them into OpenTelemetry metric data.
+// Gathering errors, e.g. inconsistent metrics of different gatherers, are returned
+// together with the metrics which could be gathered.
+func (p *prometheusProducer) Produce(ctx context.Context) ([]metricdata.ScopeMetrics, error) {
+	p.mutex.RLock()
+	gatherers := p.gatherers
+	p.mutex.RUnlock()
+
+	if len(gatherers) == 0 {
+		return nil, nil
+	}
+
+	families, err := gatherers.Gather()
+	if err != nil {
+		err = fmt.Errorf("prometheus producer gather: %w", err)
+	}
+
+	if len(families) == 0 {
+		return nil, err
+	}
+
+	logger := slogw.DefaultLogger()
+
+	now := time.Now()
+	metrics := make([]metricdata.Metrics, 0, len(families))
+
+	for _, mFamily := range families {
+		var data metricdata.Aggregation
+
+		switch mfType := mFamily.GetType(); mfType {
+		case prometheus_client.MetricType_COUNTER:
+			data = p.sum(mFamily, now)
+		case prometheus_client.MetricType_GAUGE, prometheus_client.MetricType_UNTYPED:
+			data = p.gauge(mFamily, now)
+		case prometheus_client.MetricType_HISTOGRAM:
+			data = p.histogram(mFamily, now)
+		case prometheus_client.MetricType_SUMMARY:
+			data = p.summary(mFamily, now)
+		case prometheus_client.MetricType_GAUGE_HISTOGRAM:
+			logger.DebugContext(ctx,
+				"unsupported prometheus metric type",
+				slog.String("family name", mFamily.GetName()),
+				slog.String("metric type", mfType.String()),
+			)
+
+			continue
+		}
+
+		metrics = append(metrics, metricdata.Metrics{
+			Name:        mFamily.GetName(),
+			Description: mFamily.GetHelp(),
+			Unit:        mFamily.GetUnit(),
+			Data:        data,
+		})
+	}
+
+	return []metricdata.ScopeMetrics{{
+		Scope:   instrumentation.Scope{Name: scopeName},
+		Metrics: metrics,
+	}}, err
+}
+
+// sum converts a Prometheus counter family into a cumulative, monotonic OpenTelemetry sum.
+// Counters which become identical after label filtering are added up.
+func (p *prometheusProducer) sum(mFamily *prometheus_client.MetricFamily, now time.Time) metricdata.Sum[float64] {
+	dataPoints := make([]metricdata.DataPoint[float64], 0, len(mFamily.GetMetric()))
+	indexes := make(map[attribute.Distinct]int, len(mFamily.GetMetric()))
+
+	for _, mMetric := range mFamily.GetMetric() {
+		mCounter := mMetric.GetCounter()
+		if mCounter == nil {
+			continue
+		}
+
+		attrs := labelSet(p.labels, mMetric.GetLabel())
+		if index, exists := indexes[attrs.Equivalent()]; exists {
+			dataPoints[index].Value += mCounter.GetValue()
+
+			continue
+		}
+
+		indexes[attrs.Equivalent()] = len(dataPoints)
+		dataPoints = append(dataPoints, metricdata.DataPoint[float64]{
+			Attributes: attrs,
+			StartTime:  p.startTime(mCounter.GetCreatedTimestamp()),
+			Time:       metricTime(mMetric, now),
+			Value:      mCounter.GetValue(),
+		})
+	}
+
+	return metricdata.Sum[float64]{
+		DataPoints:  dataPoints,
+		Temporality: metricdata.CumulativeTemporality,
+		IsMonotonic: true,
+	}
+}
+
+// gauge converts a Prometheus gauge or untyped family into an OpenTelemetry gauge.
+// Of the gauges which become identical after label filtering, the last one is kept.
+func (p *prometheusProducer) gauge(mFamily *prometheus_client.MetricFamily, now time.Time) metricdata.Gauge[float64] {
+	dataPoints := make([]metricdata.DataPoint[float64], 0, len(mFamily.GetMetric()))
+	indexes := make(map[attribute.Distinct]int, len(mFamily.GetMetric()))
+
+	for _, mMetric := range mFamily.GetMetric() {
+		var value float64
+
+		switch {
+		case mMetric.GetGauge() != nil:
+			value = mMetric.GetGauge().GetValue()
+		case mMetric.GetUntyped() != nil:
+			value = mMetric.GetUntyped().GetValue()
+		default:
+			continue
+		}
+
+		dataPoint := metricdata.DataPoint[float64]{
+			Attributes: labelSet(p.labels, mMetric.GetLabel()),
+			Time:       metricTime(mMetric, now),
+			Value:      value,
+		}
+
+		if index, exists := indexes[dataPoint.Attributes.Equivalent()]; exists {
+			dataPoints[index] = dataPoint
+
+			continue
+		}
+
+		indexes[dataPoint.Attributes.Equivalent()] = len(dataPoints)
+		dataPoints = append(dataPoints, dataPoint)
+	}
+
+	return metricdata.Gauge[float64]{DataPoints: dataPoints}
+}
+
+// histogram converts a Prometheus histogram family into a cumulative OpenTelemetry histogram.
+// Prometheus bucket counts are cumulative, OpenTelemetry bucket counts are not.
+// Histograms which become identical after label filtering are added up if they have the same buckets,
+// otherwise the first one is kept.
+func (p *prometheusProducer) histogram(mFamily *prometheus_client.MetricFamily, now time.Time) metricdata.Histogram[float64] {
+	dataPoints := make([]metricdata.HistogramDataPoint[float64], 0, len(mFamily.GetMetric()))
+	indexes := make(map[attribute.Distinct]int, len(mFamily.GetMetric()))
+
+	for _, mMetric := range mFamily.GetMetric() {
+		mHistogram := mMetric.GetHistogram()
+		if mHistogram == nil {
+			continue
+		}
+
+		mBuckets := mHistogram.GetBucket()
+		bounds := make([]float64, 0, len(mBuckets))
+		counts := make([]uint64, 0, len(mBuckets)+1)
+
+		var cumulative uint64
+
+		for _, bucket := range mBuckets {
+			if math.IsInf(bucket.GetUpperBound(), +1) {
+				continue
+			}
+
+			bounds = append(bounds, bucket.GetUpperBound())
+			counts = append(counts, bucket.GetCumulativeCount()-cumulative)
+			cumulative = bucket.GetCumulativeCount()
+		}
+
+		counts = append(counts, mHistogram.GetSampleCount()-cumulative)
+
+		attrs := labelSet(p.labels, mMetric.GetLabel())
+		if index, exists := indexes[attrs.Equivalent()]; exists {
+			mergeHistogram(&dataPoints[index], mHistogram, bounds, counts)
+
+			continue
+		}
+
+		indexes[attrs.Equivalent()] = len(dataPoints)
+		dataPoints = append(dataPoints, metricdata.HistogramDataPoint[float64]{
+			Attributes:   attrs,
+			StartTime:    p.startTime(mHistogram.GetCreatedTimestamp()),
+			Time:         metricTime(mMetric, now),
+			Count:        mHistogram.GetSampleCount(),
+			Sum:          mHistogram.GetSampleSum(),
+			Bounds:       bounds,
+			BucketCounts: counts,
+		})
+	}
+
+	return metricdata.Histogram[float64]{
+		DataPoints:  dataPoints,
+		Temporality: metricdata.CumulativeTemporality,
+	}
+}
+
+// mergeHistogram adds the counts and sum of a Prometheus histogram to a data point with the same bounds.
+func mergeHistogram(
+	dataPoint *metricdata.HistogramDataPoint[float64],
+	mHistogram *prometheus_client.Histogram,
+	bounds []float64,
+	counts []uint64,
+) {
+	if !slices.Equal(dataPoint.Bounds, bounds) {
+		return
+	}
+
+	dataPoint.Count += mHistogram.GetSampleCount()
+	dataPoint.Sum += mHistogram.GetSampleSum()
+
+	for i := range counts {
+		dataPoint.BucketCounts[i] += counts[i]
+	}
+}
+
+// summary converts a Prometheus summary family into an OpenTelemetry summary.
+// Summaries which become identical after label filtering are added up,
+// their quantiles cannot be merged and are dropped.
+func (p *prometheusProducer) summary(mFamily *prometheus_client.MetricFamily, now time.Time) metricdata.Summary {
+	dataPoints := make([]metricdata.SummaryDataPoint, 0, len(mFamily.GetMetric()))
+	indexes := make(map[attribute.Distinct]int, len(mFamily.GetMetric()))
+
+	for _, mMetric := range mFamily.GetMetric() {
+		mSummary := mMetric.GetSummary()
+		if mSummary == nil {
+			continue
+		}
+
+		attrs := labelSet(p.labels, mMetric.GetLabel())
+		if index, exists := indexes[attrs.Equivalent()]; exists {
+			dataPoints[index].Count += mSummary.GetSampleCount()
+			dataPoints[index].Sum += mSummary.GetSampleSum()
+			dataPoints[index].QuantileValues = nil
+
+			continue
+		}
+
+		quantiles := make([]metricdata.QuantileValue, 0, len(mSummary.GetQuantile()))
+		for _, quantile := range mSummary.GetQuantile() {
+			quantiles = append(quantiles, metricdata.QuantileValue{
+				Quantile: quantile.GetQuantile(),
+				Value:    quantile.GetValue(),
+			})
+		}
+
+		indexes[attrs.Equivalent()] = len(dataPoints)
+		dataPoints = append(dataPoints, metricdata.SummaryDataPoint{
+			Attributes:     attrs,
+			StartTime:      p.startTime(mSummary.GetCreatedTimestamp()),
+			Time:           metricTime(mMetric, now),
+			Count:          mSummary.GetSampleCount(),
+			Sum:            mSummary.GetSampleSum(),
+			QuantileValues: quantiles,
+		})
+	}
+
+	return metricdata.Summary{DataPoints: dataPoints}
+}
+
+// startTime returns the created timestamp of a Prometheus metric if it is set,
+// otherwise the producer creation time.
+func (p *prometheusProducer) startTime(created *timestamppb.Timestamp) time.Time {
+	if created == nil {
+		return p.start
+	}
+
+	return created.AsTime()
+}
+
+// metricTime returns the timestamp of a Prometheus metric if it is set, otherwise now.
+func metricTime(mMetric *prometheus_client.Metric, now time.Time) time.Time {
+	if mMetric.TimestampMs == nil {
+		return now
+	}
+
+	return time.UnixMilli(mMetric.GetTimestampMs())
+}
+
+// labelSet converts Prometheus label pairs into an attribute set,
+// dropping the label keys rejected by the label filter.
+func labelSet(filter LabelFilter, labels []*prometheus_client.LabelPair) attribute.Set {
+	kvs := make([]attribute.KeyValue, 0, len(labels))
+
+	for _, label := range labels {
+		if filter.Allowed(label.GetName()) {
+			kvs = append(kvs, attribute.String(label.GetName(), label.GetValue()))
+		}
+	}
+
+	return attribute.NewSet(kvs...)
+}
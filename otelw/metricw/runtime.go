@@ -0,0 +1,236 @@
+package metricw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"runtime/metrics"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Go runtime metrics read from runtime/metrics.
+const (
+	runtimeGoroutines      = "/sched/goroutines:goroutines"
+	runtimeGOMAXPROCS      = "/sched/gomaxprocs:threads"
+	runtimeSchedLatencies  = "/sched/latencies:seconds"
+	runtimeGOGC            = "/gc/gogc:percent"
+	runtimeMemoryLimit     = "/gc/gomemlimit:bytes"
+	runtimeHeapGoal        = "/gc/heap/goal:bytes"
+	runtimeAllocBytes      = "/gc/heap/allocs:bytes"
+	runtimeAllocObjects    = "/gc/heap/allocs:objects"
+	runtimeMemoryTotal     = "/memory/classes/total:bytes"
+	runtimeMemoryReleased  = "/memory/classes/heap/released:bytes"
+	runtimeMemoryStacks    = "/memory/classes/heap/stacks:bytes"
+	runtimeMemoryOSStacks  = "/memory/classes/os-stacks:bytes"
+	goMemoryTypeKey        = attribute.Key("go.memory.type")
+	goScheduleDurationName = "go.schedule.duration"
+)
+
+// runtimeInstruments holds the observable instruments of the Go runtime metrics,
+// named after the OpenTelemetry semantic conventions.
+type runtimeInstruments struct {
+	memoryUsed        metric.Int64ObservableUpDownCounter
+	memoryLimit       metric.Int64ObservableUpDownCounter
+	memoryAllocated   metric.Int64ObservableCounter
+	memoryAllocations metric.Int64ObservableCounter
+	memoryGCGoal      metric.Int64ObservableUpDownCounter
+	goroutineCount    metric.Int64ObservableUpDownCounter
+	processorLimit    metric.Int64ObservableUpDownCounter
+	configGOGC        metric.Int64ObservableUpDownCounter
+}
+
+// registerRuntime registers the Go runtime metrics: memory classes, allocations, GC goal,
+// goroutines and processor limit. The scheduling latency histogram is produced by the runtimeProducer.
+func (m *Metric) registerRuntime() error {
+	meter := m.provider.Meter(scopeName)
+
+	var (
+		instruments runtimeInstruments
+		errs        [8]error
+	)
+
+	instruments.memoryUsed, errs[0] = meter.Int64ObservableUpDownCounter("go.memory.used",
+		metric.WithDescription("Memory used by the Go runtime."), metric.WithUnit("By"))
+	instruments.memoryLimit, errs[1] = meter.Int64ObservableUpDownCounter("go.memory.limit",
+		metric.WithDescription("Go runtime memory limit configured by the user, if a limit exists."),
+		metric.WithUnit("By"))
+	instruments.memoryAllocated, errs[2] = meter.Int64ObservableCounter("go.memory.allocated",
+		metric.WithDescription("Memory allocated to the heap by the application."), metric.WithUnit("By"))
+	instruments.memoryAllocations, errs[3] = meter.Int64ObservableCounter("go.memory.allocations",
+		metric.WithDescription("Count of allocations to the heap by the application."),
+		metric.WithUnit("{allocation}"))
+	instruments.memoryGCGoal, errs[4] = meter.Int64ObservableUpDownCounter("go.memory.gc.goal",
+		metric.WithDescription("Heap size target for the end of the GC cycle."), metric.WithUnit("By"))
+	instruments.goroutineCount, errs[5] = meter.Int64ObservableUpDownCounter("go.goroutine.count",
+		metric.WithDescription("Count of live goroutines."), metric.WithUnit("{goroutine}"))
+	instruments.processorLimit, errs[6] = meter.Int64ObservableUpDownCounter("go.processor.limit",
+		metric.WithDescription("The number of OS threads that can execute user-level Go code simultaneously."),
+		metric.WithUnit("{thread}"))
+	instruments.configGOGC, errs[7] = meter.Int64ObservableUpDownCounter("go.config.gogc",
+		metric.WithDescription("Heap size target percentage configured by the user, otherwise 100."),
+		metric.WithUnit("%"))
+
+	if err := errors.Join(errs[:]...); err != nil {
+		return fmt.Errorf("metricw runtime: %w", err)
+	}
+
+	registration, err := meter.RegisterCallback(instruments.observe,
+		instruments.memoryUsed,
+		instruments.memoryLimit,
+		instruments.memoryAllocated,
+		instruments.memoryAllocations,
+		instruments.memoryGCGoal,
+		instruments.goroutineCount,
+		instruments.processorLimit,
+		instruments.configGOGC,
+	)
+	if err != nil {
+		return fmt.Errorf("metricw runtime register callback: %w", err)
+	}
+
+	m.instruments.mutex.Lock()
+	defer m.instruments.mutex.Unlock()
+
+	m.registrations = append(m.registrations, registration)
+
+	return nil
+}
+
+// observe reads the runtime metrics and observes the runtime instruments.
+func (i runtimeInstruments) observe(_ context.Context, observer metric.Observer) error {
+	samples := readRuntime(
+		runtimeGoroutines,
+		runtimeGOMAXPROCS,
+		runtimeGOGC,
+		runtimeMemoryLimit,
+		runtimeHeapGoal,
+		runtimeAllocBytes,
+		runtimeAllocObjects,
+		runtimeMemoryTotal,
+		runtimeMemoryReleased,
+		runtimeMemoryStacks,
+		runtimeMemoryOSStacks,
+	)
+
+	stack := samples[runtimeMemoryStacks] + samples[runtimeMemoryOSStacks]
+	other := samples[runtimeMemoryTotal] - samples[runtimeMemoryReleased] - stack
+
+	observer.ObserveInt64(i.memoryUsed, int64(stack), //nolint:gosec
+		metric.WithAttributes(goMemoryTypeKey.String("stack")))
+	observer.ObserveInt64(i.memoryUsed, int64(other), //nolint:gosec
+		metric.WithAttributes(goMemoryTypeKey.String("other")))
+
+	// The memory limit is math.MaxInt64 if it is not set.
+	if limit := samples[runtimeMemoryLimit]; limit != math.MaxInt64 {
+		observer.ObserveInt64(i.memoryLimit, int64(limit)) //nolint:gosec
+	}
+
+	observer.ObserveInt64(i.memoryAllocated, int64(samples[runtimeAllocBytes]))     //nolint:gosec
+	observer.ObserveInt64(i.memoryAllocations, int64(samples[runtimeAllocObjects])) //nolint:gosec
+	observer.ObserveInt64(i.memoryGCGoal, int64(samples[runtimeHeapGoal]))          //nolint:gosec
+	observer.ObserveInt64(i.goroutineCount, int64(samples[runtimeGoroutines]))      //nolint:gosec
+	observer.ObserveInt64(i.processorLimit, int64(samples[runtimeGOMAXPROCS]))      //nolint:gosec
+	observer.ObserveInt64(i.configGOGC, int64(samples[runtimeGOGC]))                //nolint:gosec
+
+	return nil
+}
+
+// readRuntime reads the uint64 runtime metrics by name, unsupported metrics are omitted.
+func readRuntime(names ...string) map[string]uint64 {
+	samples := make([]metrics.Sample, len(names))
+	for i, name := range names {
+		samples[i].Name = name
+	}
+
+	metrics.Read(samples)
+
+	values := make(map[string]uint64, len(samples))
+
+	for _, sample := range samples {
+		if sample.Value.Kind() == metrics.KindUint64 {
+			values[sample.Name] = sample.Value.Uint64()
+		}
+	}
+
+	return values
+}
+
+// runtimeProducer is an sdkmetric.Producer of the go.schedule.duration histogram,
+// converted from the cumulative scheduling latency distribution of the Go runtime.
+type runtimeProducer struct {
+	start time.Time
+}
+
+// newRuntimeProducer creates a runtimeProducer.
+func newRuntimeProducer() *runtimeProducer {
+	return &runtimeProducer{start: time.Now()}
+}
+
+// Produce reads the scheduling latency distribution and converts it into an OpenTelemetry histogram.
+// The runtime does not track the sum of latencies, it is estimated from the bucket midpoints.
+func (p *runtimeProducer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
+	samples := []metrics.Sample{{Name: runtimeSchedLatencies}}
+
+	metrics.Read(samples)
+
+	if samples[0].Value.Kind() != metrics.KindFloat64Histogram {
+		return nil, nil
+	}
+
+	distribution := samples[0].Value.Float64Histogram()
+
+	return []metricdata.ScopeMetrics{{
+		Scope: instrumentation.Scope{Name: scopeName},
+		Metrics: []metricdata.Metrics{{
+			Name:        goScheduleDurationName,
+			Description: "The time goroutines have spent in the scheduler in a runnable state before actually running.",
+			Unit:        "s",
+			Data: metricdata.Histogram[float64]{
+				DataPoints:  []metricdata.HistogramDataPoint[float64]{runtimeHistogram(distribution, p.start, time.Now())},
+				Temporality: metricdata.CumulativeTemporality,
+			},
+		}},
+	}}, nil
+}
+
+// runtimeHistogram converts a runtime distribution into a histogram data point.
+// The runtime buckets are lower-inclusive [b(i), b(i+1)), the outer boundaries may be infinite.
+func runtimeHistogram(distribution *metrics.Float64Histogram, start, now time.Time) metricdata.HistogramDataPoint[float64] {
+	buckets := distribution.Buckets
+
+	var (
+		count uint64
+		sum   float64
+	)
+
+	for i, bucketCount := range distribution.Counts {
+		count += bucketCount
+
+		lower, upper := buckets[i], buckets[i+1]
+
+		switch {
+		case math.IsInf(lower, -1):
+			sum += float64(bucketCount) * upper
+		case math.IsInf(upper, +1):
+			sum += float64(bucketCount) * lower
+		default:
+			sum += float64(bucketCount) * (lower + upper) / 2 //nolint:mnd
+		}
+	}
+
+	return metricdata.HistogramDataPoint[float64]{
+		Attributes:   *attribute.EmptySet(),
+		StartTime:    start,
+		Time:         now,
+		Count:        count,
+		Sum:          sum,
+		Bounds:       append([]float64(nil), buckets[1:len(buckets)-1]...),
+		BucketCounts: append([]uint64(nil), distribution.Counts...),
+	}
+}
@@ -0,0 +1,156 @@
+package metricw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/yolkhovyy/go-otelw/otelw/slogw"
+	"github.com/yolkhovyy/go-otelw/otelw/validation"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// ScrapeConfig holds the configuration of the Prometheus scrape endpoint (pull mode).
+type ScrapeConfig struct {
+	// Enable indicates whether the Prometheus scrape endpoint is enabled.
+	Enable bool `json:"enable" yaml:"enable" mapstructure:"Enable"`
+
+	// Address to serve the scrape endpoint on, e.g. ":9464".
+	// If empty, no server is started and Metric.Handler() should be mounted instead.
+	Address string `json:"address" yaml:"address" mapstructure:"Address"`
+
+	// Path of the scrape endpoint served on Address.
+	Path string `json:"path" yaml:"path" mapstructure:"Path"`
+}
+
+// Validate checks the scrape endpoint configuration, it returns nil if the endpoint is disabled.
+func (c ScrapeConfig) Validate() error {
+	if !c.Enable || c.Address == "" {
+		return nil
+	}
+
+	if !strings.HasPrefix(c.Path, "/") {
+		return validation.Field("Path", fmt.Errorf("%w %q", ErrInvalidPath, c.Path))
+	}
+
+	return nil
+}
+
+const (
+	// DefaultScrapeEnable is the default setting for enabling the scrape endpoint.
+	DefaultScrapeEnable = false
+
+	// DefaultScrapeAddress is the default address of the scrape endpoint.
+	DefaultScrapeAddress = ":9464"
+
+	// DefaultScrapePath is the default path of the scrape endpoint.
+	DefaultScrapePath = "/metrics"
+)
+
+// scrapeReadHeaderTimeout is the read header timeout of the scrape endpoint server.
+const scrapeReadHeaderTimeout = 5 * time.Second
+
+// scrape holds the Prometheus exporter reader, its registry and the optional server.
+type scrape struct {
+	reader   *otelprometheus.Exporter
+	registry *prometheus.Registry
+	handler  http.Handler
+	listener net.Listener
+	server   *http.Server
+}
+
+// newScrape creates the Prometheus exporter with the given producers, registered on a dedicated registry.
+// The OpenMetrics format, carrying exemplars, is served to scrapers negotiating it.
+// The overflow reporter, if not nil, reports the scraped instruments exceeding the cardinality limit.
+func newScrape(overflow *overflowReporter, producers ...sdkmetric.Producer) (*scrape, error) {
+	registry := prometheus.NewRegistry()
+
+	options := []otelprometheus.Option{otelprometheus.WithRegisterer(registry)}
+	for _, producer := range producers {
+		options = append(options, otelprometheus.WithProducer(producer))
+	}
+
+	reader, err := otelprometheus.New(options...)
+	if err != nil {
+		return nil, fmt.Errorf("metricw prometheus exporter: %w", err)
+	}
+
+	var gatherer prometheus.Gatherer = registry
+	if overflow != nil {
+		gatherer = overflowGatherer{Gatherer: registry, reporter: overflow}
+	}
+
+	return &scrape{
+		reader:   reader,
+		registry: registry,
+		handler:  promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}),
+	}, nil
+}
+
+// serve starts serving the scrape endpoint if an address is configured.
+func (s *scrape) serve(ctx context.Context, config ScrapeConfig) error {
+	if config.Address == "" {
+		return nil
+	}
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", config.Address)
+	if err != nil {
+		return fmt.Errorf("metricw scrape listen: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(config.Path, s.handler)
+
+	s.listener = listener
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: scrapeReadHeaderTimeout,
+	}
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slogw.DefaultLogger().ErrorContext(ctx, "metricw scrape server",
+				slog.String("address", config.Address),
+				slogw.Err(err),
+			)
+		}
+	}()
+
+	return nil
+}
+
+// option returns the MeterProvider option registering the Prometheus exporter as a reader.
+func (s *scrape) option() sdkmetric.Option {
+	return sdkmetric.WithReader(s.reader)
+}
+
+// shutdown stops the scrape endpoint server, if any.
+func (s *scrape) shutdown(ctx context.Context) error {
+	if s.server == nil {
+		return nil
+	}
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("metricw scrape server shutdown: %w", err)
+	}
+
+	return nil
+}
+
+// Handler returns the http.Handler of the Prometheus scrape endpoint, to be mounted
+// on an application router. It responds with 404 Not Found if the endpoint is disabled.
+func (m *Metric) Handler() http.Handler {
+	if m.scrape == nil {
+		return http.NotFoundHandler()
+	}
+
+	return m.scrape.handler
+}
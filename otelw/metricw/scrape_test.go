@@ -0,0 +1,132 @@
+package metricw
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen
+func TestScrape(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		config Config
+		path   string
+	}
+
+	type want struct {
+		status int
+		metric string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "handler",
+			args: args{
+				config: Config{Scrape: ScrapeConfig{Enable: true}},
+			},
+			want: want{
+				status: http.StatusOK,
+				metric: `test_requests_total{otel_scope_name="undefined",otel_scope_version=""} 1`,
+			},
+		},
+		{
+			name: "server",
+			args: args{
+				config: Config{Scrape: ScrapeConfig{Enable: true, Address: "127.0.0.1:0", Path: "/prometheus"}},
+				path:   "/prometheus",
+			},
+			want: want{
+				status: http.StatusOK,
+				metric: `test_requests_total{otel_scope_name="undefined",otel_scope_version=""} 1`,
+			},
+		},
+		{
+			name: "disabled",
+			args: args{
+				config: Config{Enable: true, Interval: DefaultInterval},
+			},
+			want: want{
+				status: http.StatusNotFound,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			met, err := Configure(ctx, test.args.config, nil, io.Discard)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, met.Shutdown(ctx))
+			}()
+
+			counter, err := met.Counter("test.requests", InstrumentOptions{})
+			require.NoError(t, err)
+
+			counter.Add(ctx, 1)
+
+			var (
+				status int
+				body   []byte
+			)
+
+			if test.args.path != "" {
+				url := "http://" + met.scrape.listener.Addr().String() + test.args.path
+
+				request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+				require.NoError(t, err)
+
+				response, err := http.DefaultClient.Do(request)
+				require.NoError(t, err)
+
+				defer response.Body.Close()
+
+				status = response.StatusCode
+				body, err = io.ReadAll(response.Body)
+				require.NoError(t, err)
+			} else {
+				recorder := httptest.NewRecorder()
+				met.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+				status = recorder.Code
+				body = recorder.Body.Bytes()
+			}
+
+			assert.Equal(t, test.want.status, status)
+			assert.Contains(t, string(body), test.want.metric)
+		})
+	}
+}
+
+func TestScrapeListenError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer listener.Close()
+
+	_, err = Configure(ctx, Config{
+		Scrape: ScrapeConfig{Enable: true, Address: listener.Addr().String(), Path: "/metrics"},
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "metricw configure scrape: metricw scrape listen")
+}
@@ -0,0 +1,180 @@
+package metricw
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/yolkhovyy/go-otelw/otelw/validation"
+	"github.com/yolkhovyy/go-utilities/stringx"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Temporality of the metrics exported via OTLP.
+type Temporality string
+
+const (
+	// TemporalityCumulative exports cumulative metrics, as required by Prometheus.
+	TemporalityCumulative Temporality = "cumulative"
+	// TemporalityDelta exports counters, observable counters and histograms as delta metrics,
+	// up-down counters and gauges as cumulative metrics.
+	TemporalityDelta Temporality = "delta"
+	// TemporalityLowMemory exports synchronous counters and histograms as delta metrics,
+	// all other instruments as cumulative metrics.
+	TemporalityLowMemory Temporality = "lowmemory"
+)
+
+// Instrument kind names used as keys of temporality overrides.
+const (
+	InstrumentCounter                 = "counter"
+	InstrumentUpDownCounter           = "up_down_counter"
+	InstrumentHistogram               = "histogram"
+	InstrumentGauge                   = "gauge"
+	InstrumentObservableCounter       = "observable_counter"
+	InstrumentObservableUpDownCounter = "observable_up_down_counter"
+	InstrumentObservableGauge         = "observable_gauge"
+)
+
+// instrumentKinds maps the instrument kind names to the SDK instrument kinds.
+var instrumentKinds = map[string]sdkmetric.InstrumentKind{ //nolint:gochecknoglobals
+	InstrumentCounter:                 sdkmetric.InstrumentKindCounter,
+	InstrumentUpDownCounter:           sdkmetric.InstrumentKindUpDownCounter,
+	InstrumentHistogram:               sdkmetric.InstrumentKindHistogram,
+	InstrumentGauge:                   sdkmetric.InstrumentKindGauge,
+	InstrumentObservableCounter:       sdkmetric.InstrumentKindObservableCounter,
+	InstrumentObservableUpDownCounter: sdkmetric.InstrumentKindObservableUpDownCounter,
+	InstrumentObservableGauge:         sdkmetric.InstrumentKindObservableGauge,
+}
+
+// String returns the string representation of the Temporality.
+func (t *Temporality) String() string {
+	return string(*t)
+}
+
+// ParseTemporality normalizes and validates a temporality name.
+// Names are case-insensitive, surrounding spaces are ignored,
+// "low_memory" is accepted as an alias of "lowmemory" and an empty name as "cumulative".
+func ParseTemporality(name string) (Temporality, error) {
+	switch temporality := Temporality(stringx.TrimSpaceToLower(name)); temporality {
+	case TemporalityCumulative, TemporalityDelta, TemporalityLowMemory:
+		return temporality, nil
+	case "low_memory":
+		return TemporalityLowMemory, nil
+	case "":
+		return TemporalityCumulative, nil
+	default:
+		return "", fmt.Errorf("parse temporality: %w %s", ErrInvalidTemporality, name)
+	}
+}
+
+// UnmarshalText unmarshals a text value into a Temporality, normalizing and validating it.
+// It is also used by encoding/json for JSON string values.
+func (t *Temporality) UnmarshalText(text []byte) error {
+	temporality, err := ParseTemporality(string(text))
+	if err != nil {
+		return fmt.Errorf("unmarshal: %w", err)
+	}
+
+	*t = temporality
+
+	return nil
+}
+
+// UnmarshalYAML unmarshals a YAML value into a Temporality, validating the temporality.
+// It returns an error if the temporality is invalid.
+func (t *Temporality) UnmarshalYAML(unmarshal func(any) error) error {
+	var strTemporality string
+	if err := unmarshal(&strTemporality); err != nil {
+		return err
+	}
+
+	return t.UnmarshalText([]byte(strTemporality))
+}
+
+// selector returns the temporality of an instrument kind.
+func (t Temporality) selector(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	switch t {
+	case TemporalityDelta:
+		switch kind { //nolint:exhaustive
+		case sdkmetric.InstrumentKindCounter,
+			sdkmetric.InstrumentKindHistogram,
+			sdkmetric.InstrumentKindObservableCounter:
+			return metricdata.DeltaTemporality
+		}
+	case TemporalityLowMemory:
+		switch kind { //nolint:exhaustive
+		case sdkmetric.InstrumentKindCounter,
+			sdkmetric.InstrumentKindHistogram:
+			return metricdata.DeltaTemporality
+		}
+	case TemporalityCumulative:
+	}
+
+	return metricdata.CumulativeTemporality
+}
+
+// validateTemporality checks the temporality and its per instrument kind overrides.
+func (c Config) validateTemporality() error {
+	var errs []error
+
+	if _, err := ParseTemporality(string(c.Temporality)); err != nil {
+		errs = append(errs, validation.Field("Temporality", err))
+	}
+
+	kinds := make([]string, 0, len(c.TemporalityOverrides))
+	for kind := range c.TemporalityOverrides {
+		kinds = append(kinds, kind)
+	}
+
+	slices.Sort(kinds)
+
+	for _, kind := range kinds {
+		path := "TemporalityOverrides." + kind
+
+		if _, exists := instrumentKinds[kind]; !exists {
+			errs = append(errs, validation.Field(path, fmt.Errorf("%w %s", ErrInvalidInstrumentKind, kind)))
+		}
+
+		if _, err := ParseTemporality(string(c.TemporalityOverrides[kind])); err != nil {
+			errs = append(errs, validation.Field(path, err))
+		}
+	}
+
+	return validation.Join("", errs...)
+}
+
+// temporalitySelector returns the temporality selector of the OTLP exporters,
+// applying the per instrument kind overrides on top of the configured temporality.
+// Invalid settings are ignored, they are reported by Validate.
+func (c Config) temporalitySelector() sdkmetric.TemporalitySelector {
+	temporality, err := ParseTemporality(string(c.Temporality))
+	if err != nil {
+		temporality = DefaultTemporality
+	}
+
+	overrides := make(map[sdkmetric.InstrumentKind]Temporality, len(c.TemporalityOverrides))
+
+	for name, override := range c.TemporalityOverrides {
+		kind, exists := instrumentKinds[name]
+		if !exists {
+			continue
+		}
+
+		if override, err := ParseTemporality(string(override)); err == nil {
+			overrides[kind] = override
+		}
+	}
+
+	return func(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+		if override, exists := overrides[kind]; exists {
+			// A delta override applies to any instrument kind, including up-down counters.
+			if override == TemporalityDelta {
+				return metricdata.DeltaTemporality
+			}
+
+			return override.selector(kind)
+		}
+
+		return temporality.selector(kind)
+	}
+}
@@ -0,0 +1,120 @@
+package metricw
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+//nolint:funlen
+func TestTemporalitySelector(t *testing.T) {
+	t.Parallel()
+
+	const (
+		cumulative = metricdata.CumulativeTemporality
+		delta      = metricdata.DeltaTemporality
+	)
+
+	type args struct {
+		config Config
+	}
+
+	type want struct {
+		temporalities map[sdkmetric.InstrumentKind]metricdata.Temporality
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "cumulative",
+			args: args{
+				config: Config{},
+			},
+			want: want{
+				temporalities: map[sdkmetric.InstrumentKind]metricdata.Temporality{
+					sdkmetric.InstrumentKindCounter:                 cumulative,
+					sdkmetric.InstrumentKindUpDownCounter:           cumulative,
+					sdkmetric.InstrumentKindHistogram:               cumulative,
+					sdkmetric.InstrumentKindGauge:                   cumulative,
+					sdkmetric.InstrumentKindObservableCounter:       cumulative,
+					sdkmetric.InstrumentKindObservableUpDownCounter: cumulative,
+					sdkmetric.InstrumentKindObservableGauge:         cumulative,
+				},
+			},
+		},
+		{
+			name: "delta",
+			args: args{
+				config: Config{Temporality: TemporalityDelta},
+			},
+			want: want{
+				temporalities: map[sdkmetric.InstrumentKind]metricdata.Temporality{
+					sdkmetric.InstrumentKindCounter:                 delta,
+					sdkmetric.InstrumentKindUpDownCounter:           cumulative,
+					sdkmetric.InstrumentKindHistogram:               delta,
+					sdkmetric.InstrumentKindGauge:                   cumulative,
+					sdkmetric.InstrumentKindObservableCounter:       delta,
+					sdkmetric.InstrumentKindObservableUpDownCounter: cumulative,
+					sdkmetric.InstrumentKindObservableGauge:         cumulative,
+				},
+			},
+		},
+		{
+			name: "lowmemory",
+			args: args{
+				config: Config{Temporality: TemporalityLowMemory},
+			},
+			want: want{
+				temporalities: map[sdkmetric.InstrumentKind]metricdata.Temporality{
+					sdkmetric.InstrumentKindCounter:                 delta,
+					sdkmetric.InstrumentKindUpDownCounter:           cumulative,
+					sdkmetric.InstrumentKindHistogram:               delta,
+					sdkmetric.InstrumentKindGauge:                   cumulative,
+					sdkmetric.InstrumentKindObservableCounter:       cumulative,
+					sdkmetric.InstrumentKindObservableUpDownCounter: cumulative,
+					sdkmetric.InstrumentKindObservableGauge:         cumulative,
+				},
+			},
+		},
+		{
+			name: "overrides",
+			args: args{
+				config: Config{
+					Temporality: TemporalityDelta,
+					TemporalityOverrides: map[string]Temporality{
+						InstrumentHistogram:     TemporalityCumulative,
+						InstrumentUpDownCounter: TemporalityDelta,
+					},
+				},
+			},
+			want: want{
+				temporalities: map[sdkmetric.InstrumentKind]metricdata.Temporality{
+					sdkmetric.InstrumentKindCounter:                 delta,
+					sdkmetric.InstrumentKindUpDownCounter:           delta,
+					sdkmetric.InstrumentKindHistogram:               cumulative,
+					sdkmetric.InstrumentKindGauge:                   cumulative,
+					sdkmetric.InstrumentKindObservableCounter:       delta,
+					sdkmetric.InstrumentKindObservableUpDownCounter: cumulative,
+					sdkmetric.InstrumentKindObservableGauge:         cumulative,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			selector := test.args.config.temporalitySelector()
+
+			for kind, temporality := range test.want.temporalities {
+				assert.Equal(t, temporality, selector(kind), kind.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package metricw
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yolkhovyy/go-otelw/otelw/validation"
+	"github.com/yolkhovyy/go-utilities/stringx"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// Aggregation of the instruments matched by a view.
+type Aggregation string
+
+const (
+	// AggregationDefault keeps the default aggregation of the instrument kind,
+	// or uses an explicit bucket histogram if buckets are configured.
+	AggregationDefault Aggregation = "default"
+	// AggregationDrop drops the instruments.
+	AggregationDrop Aggregation = "drop"
+	// AggregationExplicitBucketHistogram aggregates into a histogram with explicit bucket boundaries.
+	AggregationExplicitBucketHistogram Aggregation = "explicit_bucket_histogram"
+	// AggregationExponentialHistogram aggregates into a base-2 exponential bucket histogram.
+	AggregationExponentialHistogram Aggregation = "base2_exponential_bucket_histogram"
+)
+
+const (
+	// DefaultExponentialMaxSize is the default maximum number of buckets of exponential histograms.
+	DefaultExponentialMaxSize = 160
+
+	// DefaultExponentialMaxScale is the default maximum scale of exponential histograms.
+	DefaultExponentialMaxScale = 20
+
+	// minExponentialMaxScale is the minimum scale of exponential histograms supported by the SDK.
+	minExponentialMaxScale = -10
+)
+
+// defaultBuckets are the default explicit bucket boundaries of the SDK, used by explicit bucket
+// histogram views without buckets matching instruments other than histograms.
+var defaultBuckets = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000} //nolint:gochecknoglobals
+
+// String returns the string representation of the Aggregation.
+func (a *Aggregation) String() string {
+	return string(*a)
+}
+
+// ParseAggregation normalizes and validates an aggregation name.
+// Names are case-insensitive, surrounding spaces are ignored,
+// and an empty name is accepted as "default".
+func ParseAggregation(name string) (Aggregation, error) {
+	switch aggregation := Aggregation(stringx.TrimSpaceToLower(name)); aggregation {
+	case AggregationDefault, AggregationDrop, AggregationExplicitBucketHistogram, AggregationExponentialHistogram:
+		return aggregation, nil
+	case "":
+		return AggregationDefault, nil
+	default:
+		return "", fmt.Errorf("parse aggregation: %w %s", ErrInvalidAggregation, name)
+	}
+}
+
+// UnmarshalText unmarshals a text value into an Aggregation, normalizing and validating it.
+// It is also used by encoding/json for JSON string values.
+func (a *Aggregation) UnmarshalText(text []byte) error {
+	aggregation, err := ParseAggregation(string(text))
+	if err != nil {
+		return fmt.Errorf("unmarshal: %w", err)
+	}
+
+	*a = aggregation
+
+	return nil
+}
+
+// UnmarshalYAML unmarshals a YAML value into an Aggregation, validating the aggregation.
+// It returns an error if the aggregation is invalid.
+func (a *Aggregation) UnmarshalYAML(unmarshal func(any) error) error {
+	var strAggregation string
+	if err := unmarshal(&strAggregation); err != nil {
+		return err
+	}
+
+	return a.UnmarshalText([]byte(strAggregation))
+}
+
+// View holds the configuration of a view, customizing the metric streams
+// of the instruments it matches.
+type View struct {
+	// Instrument is the name of the matched instruments, "*" and "?" wildcards are supported.
+	Instrument string `json:"instrument" yaml:"instrument" mapstructure:"Instrument"`
+
+	// Name renames the metric stream, it is only allowed for views matching a single instrument.
+	Name string `json:"name" yaml:"name" mapstructure:"Name"`
+
+	// AttributeKeys lists the attribute keys to keep, if not empty all other attributes are dropped.
+	AttributeKeys []string `json:"attribute_keys" yaml:"attributeKeys" mapstructure:"AttributeKeys"`
+
+	// Aggregation of the matched instruments.
+	Aggregation Aggregation `json:"aggregation" yaml:"aggregation" mapstructure:"Aggregation"`
+
+	// Buckets are the explicit bucket boundaries of histograms. If empty, histograms keep
+	// their own or the SDK default boundaries, other instruments use the SDK default boundaries.
+	Buckets []float64 `json:"buckets" yaml:"buckets" mapstructure:"Buckets"`
+
+	// MaxSize is the maximum number of buckets of exponential histograms, 0 means DefaultExponentialMaxSize.
+	MaxSize int32 `json:"max_size" yaml:"maxSize" mapstructure:"MaxSize"`
+
+	// MaxScale is the maximum scale of exponential histograms, from -10 to 20,
+	// nil means DefaultExponentialMaxScale.
+	MaxScale *int32 `json:"max_scale" yaml:"maxScale" mapstructure:"MaxScale"`
+}
+
+// Validate checks the view configuration and returns all problems found,
+// qualified with the configuration field paths, or nil if there are none.
+func (v View) Validate() error {
+	var errs []error
+
+	if v.Instrument == "" {
+		errs = append(errs, validation.Field("Instrument", ErrEmptyInstrument))
+	}
+
+	if v.Name != "" && strings.ContainsAny(v.Instrument, "*?") {
+		errs = append(errs, validation.Field("Name",
+			fmt.Errorf("%w: rename of wildcard instrument %s", ErrInvalidView, v.Instrument)))
+	}
+
+	aggregation, err := ParseAggregation(string(v.Aggregation))
+	if err != nil {
+		errs = append(errs, validation.Field("Aggregation", err))
+	}
+
+	if err == nil && len(v.Buckets) > 0 &&
+		aggregation != AggregationDefault && aggregation != AggregationExplicitBucketHistogram {
+		errs = append(errs, validation.Field("Buckets",
+			fmt.Errorf("%w: buckets with %s aggregation", ErrInvalidView, aggregation)))
+	}
+
+	if v.MaxSize < 0 {
+		errs = append(errs, validation.Field("MaxSize",
+			fmt.Errorf("%w: max size %d is not positive", ErrInvalidView, v.MaxSize)))
+	}
+
+	if v.MaxScale != nil && (*v.MaxScale < minExponentialMaxScale || *v.MaxScale > DefaultExponentialMaxScale) {
+		errs = append(errs, validation.Field("MaxScale",
+			fmt.Errorf("%w: max scale %d out of range %d..%d", ErrInvalidView,
+				*v.MaxScale, minExponentialMaxScale, DefaultExponentialMaxScale)))
+	}
+
+	for i := 1; i < len(v.Buckets); i++ {
+		if v.Buckets[i] <= v.Buckets[i-1] {
+			errs = append(errs, validation.Field(fmt.Sprintf("Buckets[%d]", i),
+				fmt.Errorf("%w %v", ErrInvalidBuckets, v.Buckets)))
+
+			break
+		}
+	}
+
+	return validation.Join("", errs...)
+}
+
+// view converts the view configuration into an sdkmetric.View.
+func (v View) view() sdkmetric.View {
+	mask := sdkmetric.Stream{Name: v.Name}
+
+	if len(v.AttributeKeys) > 0 {
+		keys := make([]attribute.Key, 0, len(v.AttributeKeys))
+		for _, key := range v.AttributeKeys {
+			keys = append(keys, attribute.Key(key))
+		}
+
+		mask.AttributeFilter = attribute.NewAllowKeysFilter(keys...)
+	}
+
+	aggregation, _ := ParseAggregation(string(v.Aggregation))
+
+	switch {
+	case aggregation == AggregationDrop:
+		mask.Aggregation = sdkmetric.AggregationDrop{}
+	case aggregation == AggregationExponentialHistogram:
+		exponential := sdkmetric.AggregationBase2ExponentialHistogram{
+			MaxSize:  v.MaxSize,
+			MaxScale: DefaultExponentialMaxScale,
+		}
+
+		if exponential.MaxSize == 0 {
+			exponential.MaxSize = DefaultExponentialMaxSize
+		}
+
+		if v.MaxScale != nil {
+			exponential.MaxScale = *v.MaxScale
+		}
+
+		mask.Aggregation = exponential
+	case len(v.Buckets) > 0:
+		mask.Aggregation = sdkmetric.AggregationExplicitBucketHistogram{Boundaries: v.Buckets}
+	case aggregation == AggregationExplicitBucketHistogram:
+		return defaultBucketsView(sdkmetric.NewView(sdkmetric.Instrument{Name: v.Instrument}, mask))
+	}
+
+	return sdkmetric.NewView(sdkmetric.Instrument{Name: v.Instrument}, mask)
+}
+
+// defaultBucketsView aggregates the instruments matched by the view, other than histograms,
+// into explicit bucket histograms with the SDK default boundaries. Histograms keep the
+// default aggregation, so that the boundaries advised by the instrument are used.
+func defaultBucketsView(view sdkmetric.View) sdkmetric.View {
+	return func(instrument sdkmetric.Instrument) (sdkmetric.Stream, bool) {
+		stream, match := view(instrument)
+		if match && instrument.Kind != sdkmetric.InstrumentKindHistogram {
+			stream.Aggregation = sdkmetric.AggregationExplicitBucketHistogram{Boundaries: defaultBuckets}
+		}
+
+		return stream, match
+	}
+}
+
+// views converts the view configurations into sdkmetric.View options.
+func views(configs []View) []sdkmetric.View {
+	views := make([]sdkmetric.View, 0, len(configs))
+
+	for _, config := range configs {
+		views = append(views, config.view())
+	}
+
+	return views
+}
@@ -0,0 +1,265 @@
+package metricw
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+//nolint:funlen
+func TestViews(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		view View
+	}
+
+	type want struct {
+		name string
+		data metricdata.Aggregation
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "explicit buckets",
+			args: args{
+				view: View{Instrument: "test.duration", Buckets: []float64{1, 2}},
+			},
+			want: want{
+				name: "test.duration",
+				data: metricdata.Histogram[float64]{
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						Attributes:   attribute.NewSet(attribute.String("method", "GET"), attribute.String("path", "/")),
+						Count:        1,
+						Sum:          1.5,
+						Bounds:       []float64{1, 2},
+						BucketCounts: []uint64{0, 1, 0},
+						Min:          metricdata.NewExtrema(1.5),
+						Max:          metricdata.NewExtrema(1.5),
+					}},
+					Temporality: metricdata.CumulativeTemporality,
+				},
+			},
+		},
+		{
+			name: "rename and attribute keys",
+			args: args{
+				view: View{
+					Instrument:    "test.duration",
+					Name:          "test.latency",
+					AttributeKeys: []string{"method"},
+					Aggregation:   AggregationExplicitBucketHistogram,
+					Buckets:       []float64{1},
+				},
+			},
+			want: want{
+				name: "test.latency",
+				data: metricdata.Histogram[float64]{
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						Attributes:   attribute.NewSet(attribute.String("method", "GET")),
+						Count:        1,
+						Sum:          1.5,
+						Bounds:       []float64{1},
+						BucketCounts: []uint64{0, 1},
+						Min:          metricdata.NewExtrema(1.5),
+						Max:          metricdata.NewExtrema(1.5),
+					}},
+					Temporality: metricdata.CumulativeTemporality,
+				},
+			},
+		},
+		{
+			name: "exponential histogram",
+			args: args{
+				view: View{Instrument: "test.*", Aggregation: AggregationExponentialHistogram, MaxSize: 4},
+			},
+			want: want{
+				name: "test.duration",
+				data: metricdata.ExponentialHistogram[float64]{
+					DataPoints: []metricdata.ExponentialHistogramDataPoint[float64]{{
+						Attributes:     attribute.NewSet(attribute.String("method", "GET"), attribute.String("path", "/")),
+						Count:          1,
+						Sum:            1.5,
+						Min:            metricdata.NewExtrema(1.5),
+						Max:            metricdata.NewExtrema(1.5),
+						Scale:          20,
+						PositiveBucket: metricdata.ExponentialBucket{Offset: 613377, Counts: []uint64{1}},
+					}},
+					Temporality: metricdata.CumulativeTemporality,
+				},
+			},
+		},
+		{
+			name: "exponential histogram scale 0",
+			args: args{
+				view: View{Instrument: "test.*", Aggregation: AggregationExponentialHistogram, MaxScale: scale(0)},
+			},
+			want: want{
+				name: "test.duration",
+				data: metricdata.ExponentialHistogram[float64]{
+					DataPoints: []metricdata.ExponentialHistogramDataPoint[float64]{{
+						Attributes:     attribute.NewSet(attribute.String("method", "GET"), attribute.String("path", "/")),
+						Count:          1,
+						Sum:            1.5,
+						Min:            metricdata.NewExtrema(1.5),
+						Max:            metricdata.NewExtrema(1.5),
+						Scale:          0,
+						PositiveBucket: metricdata.ExponentialBucket{Offset: 0, Counts: []uint64{1}},
+					}},
+					Temporality: metricdata.CumulativeTemporality,
+				},
+			},
+		},
+		{
+			name: "explicit histogram default buckets",
+			args: args{
+				view: View{Instrument: "test.duration", Aggregation: AggregationExplicitBucketHistogram},
+			},
+			want: want{
+				name: "test.duration",
+				data: metricdata.Histogram[float64]{
+					DataPoints: []metricdata.HistogramDataPoint[float64]{{
+						Attributes:   attribute.NewSet(attribute.String("method", "GET"), attribute.String("path", "/")),
+						Count:        1,
+						Sum:          1.5,
+						Bounds:       defaultBuckets,
+						BucketCounts: []uint64{0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+						Min:          metricdata.NewExtrema(1.5),
+						Max:          metricdata.NewExtrema(1.5),
+					}},
+					Temporality: metricdata.CumulativeTemporality,
+				},
+			},
+		},
+		{
+			name: "drop",
+			args: args{
+				view: View{Instrument: "test.dur?tion", Aggregation: AggregationDrop},
+			},
+			want: want{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			reader := sdkmetric.NewManualReader()
+			provider := sdkmetric.NewMeterProvider(
+				sdkmetric.WithReader(reader),
+				sdkmetric.WithView(views([]View{test.args.view})...),
+			)
+
+			histogram, err := provider.Meter("test").Float64Histogram("test.duration")
+			require.NoError(t, err)
+
+			histogram.Record(ctx, 1.5, metric.WithAttributes(
+				attribute.String("method", "GET"),
+				attribute.String("path", "/"),
+			))
+
+			var resourceMetrics metricdata.ResourceMetrics
+
+			require.NoError(t, reader.Collect(ctx, &resourceMetrics))
+
+			if test.want.data == nil {
+				assert.Empty(t, resourceMetrics.ScopeMetrics)
+
+				return
+			}
+
+			require.Len(t, resourceMetrics.ScopeMetrics, 1)
+			require.Len(t, resourceMetrics.ScopeMetrics[0].Metrics, 1)
+
+			metrics := resourceMetrics.ScopeMetrics[0].Metrics[0]
+			assert.Equal(t, test.want.name, metrics.Name)
+			metricdatatest.AssertAggregationsEqual(t, test.want.data, metrics.Data, metricdatatest.IgnoreTimestamp())
+		})
+	}
+}
+
+func TestViewValidate(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		view View
+	}
+
+	type want struct {
+		errs []string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "valid",
+			args: args{
+				view: View{Instrument: "http.*", Aggregation: AggregationExponentialHistogram},
+			},
+			want: want{},
+		},
+		{
+			name: "invalid",
+			args: args{
+				view: View{Name: "foo", Aggregation: "sum", Buckets: []float64{1, 0.5}, MaxSize: -1, MaxScale: scale(21)},
+			},
+			want: want{
+				errs: []string{
+					"Instrument: empty instrument",
+					"Aggregation: parse aggregation: invalid aggregation sum",
+					"MaxSize: invalid view: max size -1 is not positive",
+					"MaxScale: invalid view: max scale 21 out of range -10..20",
+					"Buckets[1]: invalid buckets [1 0.5]",
+				},
+			},
+		},
+		{
+			name: "contradicting",
+			args: args{
+				view: View{Instrument: "http.*", Name: "foo", Aggregation: AggregationDrop, Buckets: []float64{1}},
+			},
+			want: want{
+				errs: []string{
+					"Name: invalid view: rename of wildcard instrument http.*",
+					"Buckets: invalid view: buckets with drop aggregation",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := test.args.view.Validate()
+
+			if len(test.want.errs) == 0 {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, test.want.errs, strings.Split(err.Error(), "\n"))
+			}
+		})
+	}
+}
+
+func scale(value int32) *int32 {
+	return &value
+}
@@ -1,5 +1,12 @@
 package otlp
 
+import (
+	"fmt"
+	"os"
+
+	"github.com/yolkhovyy/go-otelw/otelw/validation"
+)
+
 // Config represents the configuration settings for a OTEL protocol.
 // It includes details about the protocol, endpoint settings, security options,
 // and TLS configuration.
@@ -32,10 +39,53 @@ func Defaults() map[string]any {
 	}
 }
 
+// Validate checks the configuration and returns all problems found,
+// qualified with the configuration field paths, or nil if there are none.
+// The Endpoint may be empty if it is set with the OTEL_EXPORTER_OTLP_ENDPOINT environment variable.
+// The TLS files are required and must be readable unless Insecure is set.
+func (c Config) Validate() error {
+	var errs []error
+
+	if _, err := ParseProtocol(c.Protocol.String()); err != nil {
+		errs = append(errs, validation.Field("Protocol", err))
+	}
+
+	if c.Endpoint == "" && os.Getenv(EndpointEnv) == "" {
+		errs = append(errs, validation.Field("Endpoint", ErrEmptyEndpoint))
+	}
+
+	if !c.Insecure {
+		errs = append(errs,
+			validation.Field("ClientCertificate", validateFile(c.ClientCertificate)),
+			validation.Field("ClientKey", validateFile(c.ClientKey)),
+			validation.Field("Certificate", validateFile(c.Certificate)),
+		)
+	}
+
+	return validation.Join("", errs...)
+}
+
+// validateFile checks that a TLS file is configured and exists.
+func validateFile(path string) error {
+	if path == "" {
+		return ErrMissingFile
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("tls file: %w", err)
+	}
+
+	return nil
+}
+
 const (
 	// DefaultProtocol for telemetry collection (gRPC).
 	DefaultProtocol = GRPC
 
 	// DefaultEndpoint for the OTLP endpoint.
 	DefaultEndpoint = "localhost:4317"
+
+	// EndpointEnv is the OpenTelemetry environment variable of the OTLP endpoint,
+	// used by the exporters when Endpoint is empty.
+	EndpointEnv = "OTEL_EXPORTER_OTLP_ENDPOINT"
 )
@@ -0,0 +1,54 @@
+package otlp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest
+func TestValidateEndpoint(t *testing.T) {
+	type args struct {
+		endpoint string
+		env      string
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "endpoint",
+			args: args{endpoint: "foo:4242"},
+		},
+		{
+			name: "environment",
+			args: args{env: "http://foo:4242"},
+		},
+		{
+			name: "empty",
+			want: want{err: ErrEmptyEndpoint},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(EndpointEnv, test.args.env)
+
+			err := Config{Protocol: GRPC, Endpoint: test.args.endpoint, Insecure: true}.Validate()
+
+			if test.want.err != nil {
+				require.ErrorIs(t, err, test.want.err)
+				assert.Equal(t, "Endpoint: empty endpoint", err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package otlp
+
+import "errors"
+
+var (
+	// ErrInvalidProtocol is returned when a protocol is not equal to otlp.GRPC or otlp.HTTP, or their aliases.
+	ErrInvalidProtocol = errors.New("invalid protocol")
+
+	// ErrEmptyEndpoint is returned when config.Endpoint is empty and OTEL_EXPORTER_OTLP_ENDPOINT is not set.
+	ErrEmptyEndpoint = errors.New("empty endpoint")
+
+	// ErrMissingFile is returned when a TLS file is not configured while config.Insecure is false.
+	ErrMissingFile = errors.New("missing file")
+)
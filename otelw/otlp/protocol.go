@@ -1,5 +1,11 @@
 package otlp
 
+import (
+	"fmt"
+
+	"github.com/yolkhovyy/go-utilities/stringx"
+)
+
 // Protocol defines a type for supported communication protocols.
 type Protocol string
 
@@ -14,3 +20,41 @@ const (
 func (p Protocol) String() string {
 	return string(p)
 }
+
+// ParseProtocol normalizes and validates a protocol name.
+// Names are case-insensitive, surrounding spaces are ignored,
+// and "http" is accepted as an alias of "http/protobuf".
+func ParseProtocol(name string) (Protocol, error) {
+	switch protocol := Protocol(stringx.TrimSpaceToLower(name)); protocol {
+	case GRPC, HTTP:
+		return protocol, nil
+	case "http":
+		return HTTP, nil
+	default:
+		return "", fmt.Errorf("parse protocol: %w %s", ErrInvalidProtocol, name)
+	}
+}
+
+// UnmarshalText unmarshals a text value into a Protocol, normalizing and validating it.
+// It is also used by encoding/json for JSON string values.
+func (p *Protocol) UnmarshalText(text []byte) error {
+	protocol, err := ParseProtocol(string(text))
+	if err != nil {
+		return fmt.Errorf("unmarshal: %w", err)
+	}
+
+	*p = protocol
+
+	return nil
+}
+
+// UnmarshalYAML unmarshals a YAML value into a Protocol, validating the protocol.
+// It returns an error if the protocol is invalid.
+func (p *Protocol) UnmarshalYAML(unmarshal func(any) error) error {
+	var strProtocol string
+	if err := unmarshal(&strProtocol); err != nil {
+		return err
+	}
+
+	return p.UnmarshalText([]byte(strProtocol))
+}
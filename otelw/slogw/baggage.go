@@ -0,0 +1,126 @@
+package slogw
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// BaggageHandler is a slog.Handler that copies selected baggage members of the
+// record context onto the record as top-level string attributes, e.g. tenant.id,
+// before passing it to the next handler.
+type BaggageHandler struct {
+	// next is the next handler with all attributes and groups applied.
+	next slog.Handler
+	// base is the next handler with the attributes preceding the first group applied.
+	base slog.Handler
+	// grouped lists the groups and attributes applied after the first group.
+	grouped []groupOrAttrs
+	keys    []string
+}
+
+// groupOrAttrs is a group or attributes applied to the next handler.
+type groupOrAttrs struct {
+	group string
+	attrs []slog.Attr
+}
+
+// NewBaggageHandler creates a BaggageHandler wrapping the next handler,
+// copying the baggage members with the given keys.
+func NewBaggageHandler(next slog.Handler, keys ...string) *BaggageHandler {
+	return &BaggageHandler{
+		next: next,
+		base: next,
+		keys: keys,
+	}
+}
+
+// Enabled reports whether the next handler handles records at the given level.
+func (h *BaggageHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle adds the selected baggage members of ctx to the record and passes it to the next handler.
+// Missing members are omitted. Within groups, the members are added to the next handler
+// before the groups, so that they stay top-level.
+func (h *BaggageHandler) Handle(ctx context.Context, record slog.Record) error {
+	bag := baggage.FromContext(ctx)
+	next := h.next
+
+	var attrs []slog.Attr
+
+	for _, key := range h.keys {
+		if member := bag.Member(key); member.Key() != "" {
+			attrs = append(attrs, slog.String(key, member.Value()))
+		}
+	}
+
+	switch {
+	case len(attrs) == 0:
+	case len(h.grouped) == 0:
+		record.AddAttrs(attrs...)
+	default:
+		next = h.base.WithAttrs(attrs)
+
+		for _, goa := range h.grouped {
+			if goa.group != "" {
+				next = next.WithGroup(goa.group)
+			} else {
+				next = next.WithAttrs(goa.attrs)
+			}
+		}
+	}
+
+	if err := next.Handle(ctx, record); err != nil {
+		return fmt.Errorf("baggage handler: %w", err)
+	}
+
+	return nil
+}
+
+// WithAttrs returns a new BaggageHandler whose next handler includes the given attributes.
+func (h *BaggageHandler) WithAttrs(attrs []slog.Attr) slog.Handler { //nolint:ireturn
+	if len(h.grouped) == 0 {
+		next := h.next.WithAttrs(attrs)
+
+		return &BaggageHandler{
+			next: next,
+			base: next,
+			keys: h.keys,
+		}
+	}
+
+	return h.with(groupOrAttrs{attrs: attrs})
+}
+
+// WithGroup returns a new BaggageHandler whose next handler qualifies subsequent attributes with the group name.
+// The baggage members are not qualified.
+func (h *BaggageHandler) WithGroup(name string) slog.Handler { //nolint:ireturn
+	if name == "" {
+		return h
+	}
+
+	return h.with(groupOrAttrs{group: name})
+}
+
+// with returns a new BaggageHandler with the group or attributes applied after the first group.
+func (h *BaggageHandler) with(goa groupOrAttrs) *BaggageHandler {
+	next := h.next
+	if goa.group != "" {
+		next = next.WithGroup(goa.group)
+	} else {
+		next = next.WithAttrs(goa.attrs)
+	}
+
+	grouped := make([]groupOrAttrs, 0, len(h.grouped)+1)
+	grouped = append(grouped, h.grouped...)
+
+	return &BaggageHandler{
+		next:    next,
+		base:    h.base,
+		grouped: append(grouped, goa),
+		keys:    h.keys,
+	}
+}
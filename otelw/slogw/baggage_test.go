@@ -0,0 +1,52 @@
+package slogw
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+func TestBaggageHandler(t *testing.T) {
+	t.Parallel()
+
+	tenant, err := baggage.NewMemberRaw("tenant.id", "acme")
+	require.NoError(t, err)
+
+	user, err := baggage.NewMemberRaw("user.id", "42")
+	require.NoError(t, err)
+
+	bag, err := baggage.New(tenant, user)
+	require.NoError(t, err)
+
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	var buffer bytes.Buffer
+
+	handler := NewBaggageHandler(slog.NewTextHandler(&buffer, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	}), "tenant.id", "request.origin")
+
+	logger := slog.New(handler).With(slog.String("service", "test"))
+	logger.InfoContext(ctx, "hello")
+	logger.InfoContext(context.Background(), "bye")
+
+	grouped := logger.WithGroup("request").With(slog.String("method", "GET")).WithGroup("")
+	grouped.InfoContext(ctx, "served", slog.Int("status", 200))
+	grouped.InfoContext(context.Background(), "served", slog.Int("status", 500))
+
+	assert.Equal(t, "level=INFO msg=hello service=test tenant.id=acme\n"+
+		"level=INFO msg=bye service=test\n"+
+		"level=INFO msg=served service=test tenant.id=acme request.method=GET request.status=200\n"+
+		"level=INFO msg=served service=test request.method=GET request.status=500\n", buffer.String())
+}
@@ -1,9 +1,13 @@
 package slogw
 
 import (
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/yolkhovyy/go-otelw/otelw/otlp"
+	"github.com/yolkhovyy/go-otelw/otelw/tracew"
+	"github.com/yolkhovyy/go-otelw/otelw/validation"
 )
 
 // Config holds the configuration settings for the slogw package.
@@ -16,15 +20,33 @@ type Config struct {
 	// Caller specifies whether to include caller information in logs.
 	Caller bool `json:"caller" yaml:"caller" mapstructure:"Caller"`
 
-	// Format defines the output format of the logs - json (default), console.
+	// Format defines the output format of the logs - json (default), console, logfmt, pretty.
 	Format Format `json:"format" yaml:"format" mapstructure:"Format"`
 
-	// Level sets the minimum log level - error, warn, info (default), debug.
+	// Level sets the minimum log level - trace, debug, info (default), warn, error, fatal, panic, disabled.
 	Level string `json:"level" yaml:"level" mapstructure:"Level"`
 
 	// TimeFormat specifies the format for timestamps in logs.
 	TimeFormat string `json:"time_format" yaml:"timeFormat" mapstructure:"TimeFormat"`
 
+	// Fields specifies the order of the logfmt and pretty format fields -
+	// time, level, trace, source, message (default order), omitted fields are not written.
+	Fields []string `json:"fields" yaml:"fields" mapstructure:"Fields"`
+
+	// SpanEvents specifies whether to mirror log records as events on the recording span.
+	SpanEvents bool `json:"span_events" yaml:"spanEvents" mapstructure:"SpanEvents"`
+
+	// SpanErrorStatus specifies whether error-level records mirrored as span events
+	// also set the span status to Error.
+	SpanErrorStatus bool `json:"span_error_status" yaml:"spanErrorStatus" mapstructure:"SpanErrorStatus"`
+
+	// Exceptions specifies whether to expand error attributes into type, message and stacktrace
+	// attributes prefixed with their key, e.g. exception.type for slogw.Err or cause.type.
+	Exceptions bool `json:"exceptions" yaml:"exceptions" mapstructure:"Exceptions"`
+
+	// BaggageKeys lists the baggage members copied onto every log record as attributes, e.g. tenant.id.
+	BaggageKeys []string `json:"baggage_keys" yaml:"baggageKeys" mapstructure:"BaggageKeys"`
+
 	// OTLP holds the configuration for the OTEL protocol.
 	OTLP otlp.Config `json:"otlp" yaml:"otlp" mapstructure:"OTLP"`
 }
@@ -41,6 +63,9 @@ func Defaults() map[string]any {
 	defaults["Format"] = DefaultFormat
 	defaults["Level"] = DefaultLevel
 	defaults["TimeFormat"] = DefaultTimeFormat
+	defaults["SpanEvents"] = DefaultSpanEvents
+	defaults["SpanErrorStatus"] = DefaultSpanErrorStatus
+	defaults["Exceptions"] = DefaultExceptions
 
 	for k, v := range otlp.Defaults() {
 		defaults["OTLP."+k] = v
@@ -49,6 +74,43 @@ func Defaults() map[string]any {
 	return defaults
 }
 
+// Validate checks the configuration and returns all problems found,
+// qualified with the configuration field paths, or nil if there are none.
+// The OTLP configuration is validated only if logs are exported with OTLP.
+func (c Config) Validate() error {
+	return c.validate(true)
+}
+
+// validate checks the configuration, the OTLP configuration is checked only if withOTLP is true.
+func (c Config) validate(withOTLP bool) error {
+	var errs []error
+
+	if c.Format != "" {
+		if _, err := ParseFormat(string(c.Format)); err != nil {
+			errs = append(errs, validation.Field("Format", err))
+		}
+	}
+
+	if _, err := ParseLevel(c.Level); err != nil {
+		errs = append(errs, validation.Field("Level", err))
+	}
+
+	for i, field := range c.Fields {
+		if !slices.Contains(DefaultFields, field) {
+			errs = append(errs, validation.Field(fmt.Sprintf("Fields[%d]", i),
+				fmt.Errorf("%w %s", ErrInvalidField, field)))
+		}
+	}
+
+	errs = append(errs, tracew.ValidateBaggageKeys(c.BaggageKeys)...)
+
+	if withOTLP && c.Enable && (c.Format == "" || c.Format == JSON) {
+		errs = append(errs, validation.Join("OTLP", c.OTLP.Validate()))
+	}
+
+	return validation.Join("", errs...)
+}
+
 const (
 	// DefaultEnable is the default setting for enabling logging.
 	DefaultEnable = false
@@ -64,4 +126,13 @@ const (
 
 	// DefaultTimeFormat is the default format for timestamps in logs.
 	DefaultTimeFormat = time.RFC3339
+
+	// DefaultSpanEvents is the default setting for mirroring log records as span events.
+	DefaultSpanEvents = false
+
+	// DefaultSpanErrorStatus is the default setting for marking spans as Error on error-level records.
+	DefaultSpanErrorStatus = false
+
+	// DefaultExceptions is the default setting for expanding error attributes into exception attributes.
+	DefaultExceptions = false
 )
@@ -0,0 +1,360 @@
+package slogw
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Console fields which can be ordered with Config.Fields.
+const (
+	FieldTime    = "time"
+	FieldLevel   = "level"
+	FieldTrace   = "trace"
+	FieldSource  = "source"
+	FieldMessage = "message"
+)
+
+// DefaultFields is the default order of console fields, record attributes are always last.
+var DefaultFields = []string{FieldTime, FieldLevel, FieldTrace, FieldSource, FieldMessage} //nolint:gochecknoglobals
+
+// ANSI escape sequences used by the pretty format.
+const (
+	colorReset   = "\033[0m"
+	colorDim     = "\033[2m"
+	colorRed     = "\033[31m"
+	colorGreen   = "\033[32m"
+	colorYellow  = "\033[33m"
+	colorBlue    = "\033[34m"
+	colorMagenta = "\033[35m"
+	colorCyan    = "\033[36m"
+)
+
+const (
+	// prettyMessageWidth is the column width of messages in the pretty format.
+	prettyMessageWidth = 40
+
+	// shortTraceIDLength is the number of trace ID hex digits shown in the pretty format.
+	shortTraceIDLength = 8
+)
+
+// ConsoleOptions holds the options of a ConsoleHandler.
+type ConsoleOptions struct {
+	// Format is either Logfmt or Pretty.
+	Format Format
+
+	// Level is the minimum level of handled records.
+	Level slog.Leveler
+
+	// AddSource specifies whether to output the source code position of the log statement.
+	AddSource bool
+
+	// TimeFormat specifies the format for timestamps.
+	TimeFormat string
+
+	// Color specifies whether to colorize the Pretty format.
+	Color bool
+
+	// Fields specifies the order of console fields, omitted fields are not written.
+	Fields []string
+}
+
+// ConsoleHandler is a slog.Handler writing records in the logfmt or the pretty console format.
+// Trace and span IDs are taken from the span context of the record context.
+type ConsoleHandler struct {
+	options ConsoleOptions
+	mutex   *sync.Mutex
+	writer  io.Writer
+	attrs   string
+	group   string
+}
+
+// NewConsoleHandler creates a ConsoleHandler writing to the given writer.
+func NewConsoleHandler(writer io.Writer, options ConsoleOptions) *ConsoleHandler {
+	if options.Level == nil {
+		options.Level = slog.LevelInfo
+	}
+
+	if options.TimeFormat == "" {
+		options.TimeFormat = DefaultTimeFormat
+	}
+
+	if len(options.Fields) == 0 {
+		options.Fields = DefaultFields
+	}
+
+	return &ConsoleHandler{
+		options: options,
+		mutex:   &sync.Mutex{},
+		writer:  writer,
+	}
+}
+
+// Enabled reports whether the handler handles records at the given level.
+func (h *ConsoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.options.Level.Level()
+}
+
+// Handle formats the record as a single line and writes it.
+func (h *ConsoleHandler) Handle(ctx context.Context, record slog.Record) error {
+	var builder strings.Builder
+
+	for _, field := range h.options.Fields {
+		start := builder.Len()
+
+		switch field {
+		case FieldTime:
+			h.appendTime(&builder, record.Time)
+		case FieldLevel:
+			h.appendLevel(&builder, record.Level)
+		case FieldTrace:
+			h.appendTrace(&builder, trace.SpanContextFromContext(ctx))
+		case FieldSource:
+			h.appendSource(&builder, record.PC)
+		case FieldMessage:
+			h.appendMessage(&builder, record.Message)
+		}
+
+		if builder.Len() > start {
+			builder.WriteByte(' ')
+		}
+	}
+
+	builder.WriteString(h.attrs)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		h.appendAttr(&builder, h.group, attr)
+
+		return true
+	})
+
+	line := strings.TrimRight(builder.String(), " ") + "\n"
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if _, err := io.WriteString(h.writer, line); err != nil {
+		return fmt.Errorf("console handler write: %w", err)
+	}
+
+	return nil
+}
+
+// WithAttrs returns a new ConsoleHandler which writes the given attributes with every record.
+func (h *ConsoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler { //nolint:ireturn
+	var builder strings.Builder
+
+	builder.WriteString(h.attrs)
+
+	for _, attr := range attrs {
+		h.appendAttr(&builder, h.group, attr)
+	}
+
+	handler := *h
+	handler.attrs = builder.String()
+
+	return &handler
+}
+
+// WithGroup returns a new ConsoleHandler that qualifies subsequent attributes with the group name.
+func (h *ConsoleHandler) WithGroup(name string) slog.Handler { //nolint:ireturn
+	if name == "" {
+		return h
+	}
+
+	handler := *h
+	if h.group == "" {
+		handler.group = name
+	} else {
+		handler.group = h.group + "." + name
+	}
+
+	return &handler
+}
+
+func (h *ConsoleHandler) appendTime(builder *strings.Builder, t time.Time) {
+	if t.IsZero() {
+		return
+	}
+
+	if h.options.Format == Pretty {
+		h.colorize(builder, colorDim, t.Format(h.options.TimeFormat))
+
+		return
+	}
+
+	h.appendKeyValue(builder, "time", t.Format(h.options.TimeFormat))
+}
+
+func (h *ConsoleHandler) appendLevel(builder *strings.Builder, level slog.Level) {
+	if h.options.Format != Pretty {
+		h.appendKeyValue(builder, "level", strings.ToLower(level.String()))
+
+		return
+	}
+
+	switch {
+	case level >= slog.LevelError:
+		h.colorize(builder, colorRed, "ERR")
+	case level >= slog.LevelWarn:
+		h.colorize(builder, colorYellow, "WRN")
+	case level >= slog.LevelInfo:
+		h.colorize(builder, colorGreen, "INF")
+	default:
+		h.colorize(builder, colorBlue, "DBG")
+	}
+}
+
+func (h *ConsoleHandler) appendTrace(builder *strings.Builder, spanContext trace.SpanContext) {
+	if !spanContext.IsValid() {
+		return
+	}
+
+	if h.options.Format == Pretty {
+		traceID := spanContext.TraceID().String()
+		h.colorize(builder, colorMagenta, "["+traceID[:shortTraceIDLength]+"]")
+
+		return
+	}
+
+	h.appendKeyValue(builder, "trace_id", spanContext.TraceID().String())
+	builder.WriteByte(' ')
+	h.appendKeyValue(builder, "span_id", spanContext.SpanID().String())
+}
+
+func (h *ConsoleHandler) appendSource(builder *strings.Builder, pc uintptr) {
+	if !h.options.AddSource || pc == 0 {
+		return
+	}
+
+	frames := runtime.CallersFrames([]uintptr{pc})
+	frame, _ := frames.Next()
+	source := frame.File + ":" + strconv.Itoa(frame.Line)
+
+	if h.options.Format == Pretty {
+		h.colorize(builder, colorDim, source)
+
+		return
+	}
+
+	h.appendKeyValue(builder, "source", source)
+}
+
+func (h *ConsoleHandler) appendMessage(builder *strings.Builder, message string) {
+	if h.options.Format == Pretty {
+		builder.WriteString(fmt.Sprintf("%-*s", prettyMessageWidth, message))
+
+		return
+	}
+
+	h.appendKeyValue(builder, "msg", message)
+}
+
+// appendAttr writes a record attribute, group attributes are flattened with dotted keys.
+func (h *ConsoleHandler) appendAttr(builder *strings.Builder, prefix string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	key := attr.Key
+	if prefix != "" && key != "" {
+		key = prefix + "." + key
+	} else if key == "" {
+		key = prefix
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		for _, a := range attr.Value.Group() {
+			h.appendAttr(builder, key, a)
+		}
+
+		return
+	}
+
+	if key == "" {
+		return
+	}
+
+	var value string
+
+	switch attr.Value.Kind() {
+	case slog.KindTime:
+		value = attr.Value.Time().Format(h.options.TimeFormat)
+	case slog.KindAny:
+		if err, ok := attr.Value.Any().(error); ok {
+			value = err.Error()
+		} else {
+			value = attr.Value.String()
+		}
+	default:
+		value = attr.Value.String()
+	}
+
+	h.appendKeyValue(builder, key, value)
+	builder.WriteByte(' ')
+}
+
+func (h *ConsoleHandler) appendKeyValue(builder *strings.Builder, key, value string) {
+	if h.options.Format == Pretty {
+		h.colorize(builder, colorCyan, key+"=")
+	} else {
+		builder.WriteString(key + "=")
+	}
+
+	builder.WriteString(quote(value))
+}
+
+func (h *ConsoleHandler) colorize(builder *strings.Builder, color, text string) {
+	if h.options.Color {
+		builder.WriteString(color + text + colorReset)
+	} else {
+		builder.WriteString(text)
+	}
+}
+
+// quote quotes a logfmt value if it is empty or contains spaces, quotes, equal signs
+// or non-printable characters.
+func quote(value string) string {
+	if value == "" {
+		return `""`
+	}
+
+	for _, r := range value {
+		if r == ' ' || r == '"' || r == '=' || !unicode.IsPrint(r) {
+			return strconv.Quote(value)
+		}
+	}
+
+	return value
+}
+
+// isTerminal reports whether the writer is a terminal, colors are also
+// disabled when the NO_COLOR environment variable is set (see https://no-color.org/).
+func isTerminal(writer io.Writer) bool {
+	if _, exists := os.LookupEnv("NO_COLOR"); exists {
+		return false
+	}
+
+	file, ok := writer.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,258 @@
+package slogw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// ExceptionKey is the key of the attribute returned by Err.
+const ExceptionKey = "exception"
+
+// maxStackDepth limits the number of frames captured at log time.
+const maxStackDepth = 32
+
+// exception is a slog.LogValuer carrying an error and its stack trace.
+type exception struct {
+	err   error
+	stack []uintptr
+}
+
+// Err returns an attribute describing err with the OpenTelemetry exception
+// semantic conventions: exception.type, exception.message and exception.stacktrace.
+// The stack trace is extracted from the wrapped errors if available,
+// otherwise it is captured at the call site of Err.
+// Err returns an empty attribute, which is ignored by handlers, if err is nil.
+//
+// Usage:
+//
+//	logger.ErrorContext(ctx, "echo", slogw.Err(err))
+func Err(err error) slog.Attr {
+	if err == nil {
+		return slog.Attr{}
+	}
+
+	stack := errorStack(err)
+	if stack == nil {
+		pcs := make([]uintptr, maxStackDepth)
+		stack = pcs[:runtime.Callers(2, pcs)] //nolint:mnd
+	}
+
+	return slog.Any(ExceptionKey, exception{err: err, stack: stack})
+}
+
+// LogValue returns the exception attributes as a group.
+func (e exception) LogValue() slog.Value {
+	return slog.GroupValue(exceptionAttrs("", e.err, e.stack)...)
+}
+
+// exceptionAttrs returns the exception attributes of err, prefixed with prefix.
+func exceptionAttrs(prefix string, err error, stack []uintptr) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String(prefix+"type", errorType(err)),
+		slog.String(prefix+"message", errorMessage(err)),
+	}
+
+	if len(stack) > 0 {
+		attrs = append(attrs, slog.String(prefix+"stacktrace", stackTrace(stack)))
+	}
+
+	return attrs
+}
+
+// errorType returns the fully qualified type name of err, with the import path of its package,
+// e.g. *io/fs.PathError. Pointer types are prefixed with an asterisk.
+func errorType(err error) string {
+	var pointer string
+
+	t := reflect.TypeOf(err)
+	if t.Kind() == reflect.Pointer {
+		pointer = "*"
+		t = t.Elem()
+	}
+
+	if t.PkgPath() == "" || t.Name() == "" {
+		return pointer + t.String()
+	}
+
+	return pointer + t.PkgPath() + "." + t.Name()
+}
+
+// errorMessage renders err on a single line.
+// Errors joined with errors.Join are rendered as a list of their messages.
+func errorMessage(err error) string {
+	joined, ok := err.(interface{ Unwrap() []error }) //nolint:errorlint
+	if !ok {
+		return err.Error()
+	}
+
+	errs := joined.Unwrap()
+	messages := make([]string, 0, len(errs))
+
+	for _, e := range errs {
+		if e != nil {
+			messages = append(messages, errorMessage(e))
+		}
+	}
+
+	if len(messages) == 1 {
+		return messages[0]
+	}
+
+	return fmt.Sprintf("%d errors: [%s]", len(messages), strings.Join(messages, "; "))
+}
+
+// errorStack returns the first stack trace found in the chain of err,
+// including errors joined with errors.Join, or nil if there is none.
+// It supports errors providing Callers() []uintptr, and errors providing
+// StackTrace() returning a slice of program counters, like github.com/pkg/errors.
+func errorStack(err error) []uintptr {
+	if err == nil {
+		return nil
+	}
+
+	var callers interface{ Callers() []uintptr }
+	if errors.As(err, &callers) {
+		return callers.Callers()
+	}
+
+	if stack := reflectStack(err); stack != nil {
+		return stack
+	}
+
+	switch wrapped := err.(type) { //nolint:errorlint
+	case interface{ Unwrap() error }:
+		return errorStack(wrapped.Unwrap())
+	case interface{ Unwrap() []error }:
+		for _, e := range wrapped.Unwrap() {
+			if stack := errorStack(e); stack != nil {
+				return stack
+			}
+		}
+	}
+
+	return nil
+}
+
+// reflectStack returns the program counters provided by the StackTrace method of err, if any.
+func reflectStack(err error) []uintptr {
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil
+	}
+
+	out := method.Type().Out(0)
+	if out.Kind() != reflect.Slice || out.Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+
+	frames := method.Call(nil)[0]
+	stack := make([]uintptr, frames.Len())
+
+	for i := range stack {
+		stack[i] = uintptr(frames.Index(i).Uint())
+	}
+
+	return stack
+}
+
+// stackTrace renders program counters in the format of Go panic stack traces.
+func stackTrace(stack []uintptr) string {
+	var builder strings.Builder
+
+	frames := runtime.CallersFrames(stack)
+
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" {
+			fmt.Fprintf(&builder, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return builder.String()
+}
+
+// ExceptionHandler is a slog.Handler that expands error attributes into flat attributes
+// prefixed with their key before passing records to the next handler: the attribute created by Err
+// into exception.type, exception.message and exception.stacktrace, other error attributes
+// e.g. into cause.type, cause.message and cause.stacktrace, so that the keys of a record stay unique.
+type ExceptionHandler struct {
+	next slog.Handler
+}
+
+// NewExceptionHandler creates an ExceptionHandler wrapping the next handler.
+func NewExceptionHandler(next slog.Handler) *ExceptionHandler {
+	return &ExceptionHandler{next: next}
+}
+
+// Enabled reports whether the next handler handles records at the given level.
+func (h *ExceptionHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle expands the error attributes of the record and passes it to the next handler.
+func (h *ExceptionHandler) Handle(ctx context.Context, record slog.Record) error {
+	expanded := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		expanded.AddAttrs(expandErrors(attr)...)
+
+		return true
+	})
+
+	if err := h.next.Handle(ctx, expanded); err != nil {
+		return fmt.Errorf("exception handler: %w", err)
+	}
+
+	return nil
+}
+
+// WithAttrs returns a new ExceptionHandler whose next handler has the expanded attributes.
+func (h *ExceptionHandler) WithAttrs(attrs []slog.Attr) slog.Handler { //nolint:ireturn
+	expanded := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		expanded = append(expanded, expandErrors(attr)...)
+	}
+
+	return &ExceptionHandler{next: h.next.WithAttrs(expanded)}
+}
+
+// WithGroup returns a new ExceptionHandler whose next handler has the given group.
+func (h *ExceptionHandler) WithGroup(name string) slog.Handler { //nolint:ireturn
+	return &ExceptionHandler{next: h.next.WithGroup(name)}
+}
+
+// expandErrors replaces error attributes with the exception attributes prefixed with their key.
+func expandErrors(attr slog.Attr) []slog.Attr {
+	prefix := attr.Key + "."
+
+	switch value := attr.Value.Any().(type) {
+	case exception:
+		return exceptionAttrs(prefix, value.err, value.stack)
+	case error:
+		return exceptionAttrs(prefix, value, errorStack(value))
+	}
+
+	if attr.Value.Kind() != slog.KindGroup {
+		return []slog.Attr{attr}
+	}
+
+	group := attr.Value.Group()
+	expanded := make([]any, 0, len(group))
+
+	for _, a := range group {
+		for _, e := range expandErrors(a) {
+			expanded = append(expanded, e)
+		}
+	}
+
+	return []slog.Attr{slog.Group(attr.Key, expanded...)}
+}
@@ -0,0 +1,147 @@
+package slogw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("test error")
+
+// valueError is an error of a named value type.
+type valueError struct{}
+
+func (valueError) Error() string { return "value error" }
+
+//nolint:funlen
+func TestExceptionHandler(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		attr slog.Attr
+	}
+
+	type want struct {
+		typ        string
+		message    string
+		stacktrace bool
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "err",
+			args: args{
+				attr: Err(fmt.Errorf("wrapped: %w", errTest)),
+			},
+			want: want{
+				typ:        "*fmt.wrapError",
+				message:    "wrapped: test error",
+				stacktrace: true,
+			},
+		},
+		{
+			name: "err joined",
+			args: args{
+				attr: Err(errors.Join(errTest, fmt.Errorf("wrapped: %w", errTest))),
+			},
+			want: want{
+				typ:        "*errors.joinError",
+				message:    "2 errors: [test error; wrapped: test error]",
+				stacktrace: true,
+			},
+		},
+		{
+			name: "plain error",
+			args: args{
+				attr: slog.Any("error", errTest),
+			},
+			want: want{
+				typ:        "*errors.errorString",
+				message:    "test error",
+				stacktrace: false,
+			},
+		},
+		{
+			name: "path error",
+			args: args{
+				attr: slog.Any("error", &fs.PathError{Op: "open", Path: "missing", Err: fs.ErrNotExist}),
+			},
+			want: want{
+				typ:     "*io/fs.PathError",
+				message: "open missing: file does not exist",
+			},
+		},
+		{
+			name: "value error",
+			args: args{
+				attr: slog.Any("error", valueError{}),
+			},
+			want: want{
+				typ:     "github.com/yolkhovyy/go-otelw/otelw/slogw.valueError",
+				message: "value error",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var builder strings.Builder
+
+			logger := slog.New(NewExceptionHandler(slog.NewJSONHandler(&builder, nil)))
+			logger.ErrorContext(context.Background(), "test log", test.args.attr)
+
+			var record map[string]any
+			require.NoError(t, json.Unmarshal([]byte(builder.String()), &record))
+
+			prefix := test.args.attr.Key + "."
+
+			assert.Equal(t, test.want.typ, record[prefix+"type"])
+			assert.Equal(t, test.want.message, record[prefix+"message"])
+
+			stacktrace, exists := record[prefix+"stacktrace"]
+			assert.Equal(t, test.want.stacktrace, exists)
+
+			if test.want.stacktrace {
+				assert.Contains(t, stacktrace, "TestExceptionHandler")
+			}
+		})
+	}
+}
+
+func TestExceptionHandlerErrors(t *testing.T) {
+	t.Parallel()
+
+	var builder strings.Builder
+
+	logger := slog.New(NewExceptionHandler(slog.NewJSONHandler(&builder, nil)))
+	logger.With(slog.Any("previous", valueError{})).ErrorContext(context.Background(), "test log",
+		Err(errTest),
+		slog.Any("cause", fs.ErrNotExist),
+	)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal([]byte(builder.String()), &record))
+
+	assert.Equal(t, "test error", record["exception.message"])
+	assert.Equal(t, "file does not exist", record["cause.message"])
+	assert.Equal(t, "*errors.errorString", record["cause.type"])
+	assert.Equal(t, "value error", record["previous.message"])
+	assert.NotContains(t, record, "cause.stacktrace")
+
+	// Each key is written once.
+	assert.Equal(t, 1, strings.Count(builder.String(), `"exception.message"`))
+}
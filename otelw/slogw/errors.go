@@ -1,11 +1,28 @@
 package slogw
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/yolkhovyy/go-otelw/otelw/otlp"
+	"github.com/yolkhovyy/go-otelw/otelw/tracew"
+)
 
 var (
-	// ErrInvalidFormat is returned when config.Format is not qual to slogw.Console or slogw.JSON.
+	// ErrInvalidFormat is returned when config.Format is not equal to
+	// slogw.Console, slogw.JSON, slogw.Logfmt or slogw.Pretty.
 	ErrInvalidFormat = errors.New("invalid format")
 
-	// ErrInvalidProtocol is returned when config.Collector.Protocol is not qual to otlp.GRPC or otlp.HTTP.
-	ErrInvalidProtocol = errors.New("invalid protocol")
+	// ErrInvalidLevel is returned when config.Level is not a known level.
+	ErrInvalidLevel = errors.New("invalid level")
+
+	// ErrInvalidField is returned when config.Fields contains an unknown console field.
+	ErrInvalidField = errors.New("invalid field")
+
+	// ErrInvalidBaggageKey is returned when config.BaggageKeys contains an invalid baggage key.
+	// It is tracew.ErrInvalidBaggageKey.
+	ErrInvalidBaggageKey = tracew.ErrInvalidBaggageKey
+
+	// ErrInvalidProtocol is returned when config.OTLP.Protocol is not equal to otlp.GRPC or otlp.HTTP.
+	// It is otlp.ErrInvalidProtocol.
+	ErrInvalidProtocol = otlp.ErrInvalidProtocol
 )
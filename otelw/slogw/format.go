@@ -10,8 +10,14 @@ import (
 type Format string
 
 const (
+	// Console is the slog text format.
 	Console Format = "console"
-	JSON    Format = "json"
+	// JSON is the OpenTelemetry log record format.
+	JSON Format = "json"
+	// Logfmt is the logfmt format.
+	Logfmt Format = "logfmt"
+	// Pretty is the colorized, column-aligned console format.
+	Pretty Format = "pretty"
 )
 
 // String returns the string representation of the Format.
@@ -19,6 +25,33 @@ func (f *Format) String() string {
 	return string(*f)
 }
 
+// ParseFormat normalizes and validates a format name.
+// Names are case-insensitive, surrounding spaces are ignored,
+// and "text" is accepted as an alias of "console".
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(stringx.TrimSpaceToLower(name)); format {
+	case Console, JSON, Logfmt, Pretty:
+		return format, nil
+	case "text":
+		return Console, nil
+	default:
+		return "", fmt.Errorf("parse format: %w %s", ErrInvalidFormat, name)
+	}
+}
+
+// UnmarshalText unmarshals a text value into a Format, normalizing and validating it.
+// It is also used by encoding/json for JSON string values.
+func (f *Format) UnmarshalText(text []byte) error {
+	format, err := ParseFormat(string(text))
+	if err != nil {
+		return fmt.Errorf("unmarshal: %w", err)
+	}
+
+	*f = format
+
+	return nil
+}
+
 // UnmarshalYAML unmarshals a YAML value into a Format, validating the format.
 // It returns an error if the format is invalid.
 func (f *Format) UnmarshalYAML(unmarshal func(any) error) error {
@@ -27,13 +60,5 @@ func (f *Format) UnmarshalYAML(unmarshal func(any) error) error {
 		return err
 	}
 
-	format := Format(stringx.TrimSpaceToLower(strFormat))
-	switch format {
-	case Console, JSON:
-		*f = format
-	default:
-		return fmt.Errorf("unmarshal: %w %s", ErrInvalidFormat, strFormat)
-	}
-
-	return nil
+	return f.UnmarshalText([]byte(strFormat))
 }
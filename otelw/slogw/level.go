@@ -0,0 +1,44 @@
+package slogw
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+
+	"github.com/yolkhovyy/go-utilities/stringx"
+)
+
+// Additional levels accepted in Config.Level besides the slog levels.
+const (
+	LevelTrace    = slog.LevelDebug - 4
+	LevelFatal    = slog.LevelError + 4
+	LevelPanic    = slog.LevelError + 8
+	LevelDisabled = slog.Level(math.MaxInt32)
+)
+
+// ParseLevel parses a level name - trace, debug, info, warn, error, fatal, panic, disabled.
+// Names are case-insensitive, and slog level names with offsets, e.g. "warn+2", are accepted.
+// An empty name is the DefaultLevel.
+func ParseLevel(name string) (slog.Level, error) {
+	name = stringx.TrimSpaceToLower(name)
+
+	switch name {
+	case "":
+		return ParseLevel(DefaultLevel)
+	case "trace":
+		return LevelTrace, nil
+	case "fatal":
+		return LevelFatal, nil
+	case "panic":
+		return LevelPanic, nil
+	case "disabled":
+		return LevelDisabled, nil
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return level, fmt.Errorf("parse level: %w %s", ErrInvalidLevel, name)
+	}
+
+	return level, nil
+}
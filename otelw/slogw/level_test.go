@@ -0,0 +1,29 @@
+package slogw
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	t.Parallel()
+
+	for name, want := range map[string]slog.Level{
+		"":         slog.LevelInfo,
+		" info ":   slog.LevelInfo,
+		"Trace":    LevelTrace,
+		" warn+2 ": slog.LevelWarn + 2,
+		"ERROR-1":  slog.LevelError - 1,
+		"disabled": LevelDisabled,
+	} {
+		level, err := ParseLevel(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, level, name)
+	}
+
+	_, err := ParseLevel("verbose")
+	require.ErrorIs(t, err, ErrInvalidLevel)
+}
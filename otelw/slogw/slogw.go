@@ -40,22 +40,25 @@ func Configure( //nolint:cyclop,funlen
 	attrs []attribute.KeyValue,
 	writers ...io.Writer,
 ) (*Logger, error) {
-	if config.Format == Console {
-		var level slog.Level
-		if err := level.UnmarshalText([]byte(config.Level)); err != nil {
-			level = slog.LevelDebug
-		}
+	if err := config.validate(len(writers) == 0); err != nil {
+		return nil, fmt.Errorf("slogw configure: %w", err)
+	}
 
-		logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-			Level:     level,
-			AddSource: config.Caller,
-		}))
+	switch config.Format {
+	case Console, Logfmt, Pretty:
+		consoleHandler, err := consoleHandler(config, writers...)
+		if err != nil {
+			return nil, fmt.Errorf("slogw configure: %w", err)
+		}
 
+		logger := slog.New(handler(config, consoleHandler))
 		slog.SetDefault(logger)
 
 		return &Logger{
-			Logger: slog.Default(),
+			Logger: logger,
 		}, nil
+	case JSON:
+		// Exported by the OpenTelemetry log pipeline configured below.
 	}
 
 	exporter, err := exporter(ctx, config, writers...)
@@ -80,19 +83,67 @@ func Configure( //nolint:cyclop,funlen
 		serviceName = value.AsString()
 	}
 
-	slog.SetDefault(otelslog.NewLogger(
+	logger := slog.New(handler(config, otelslog.NewHandler(
 		serviceName,
 		otelslog.WithLoggerProvider(provider),
 		otelslog.WithSource(config.Caller),
-	))
+	)))
+	slog.SetDefault(logger)
 
 	return &Logger{
-		Logger:   slog.Default(),
+		Logger:   logger,
 		exporter: exporter,
 		provider: provider,
 	}, nil
 }
 
+// consoleHandler creates the handler of the console, logfmt and pretty formats.
+// It writes to the given writers, or to stdout if there are none.
+func consoleHandler(config Config, writers ...io.Writer) (slog.Handler, error) { //nolint:ireturn
+	level, err := ParseLevel(config.Level)
+	if err != nil {
+		return nil, fmt.Errorf("console handler: %w", err)
+	}
+
+	var output io.Writer = os.Stdout
+	if len(writers) > 0 {
+		output = io.MultiWriter(writers...)
+	}
+
+	if config.Format == Console {
+		return slog.NewTextHandler(output, &slog.HandlerOptions{
+			Level:     level,
+			AddSource: config.Caller,
+		}), nil
+	}
+
+	return NewConsoleHandler(output, ConsoleOptions{
+		Format:     config.Format,
+		Level:      level,
+		AddSource:  config.Caller,
+		TimeFormat: config.TimeFormat,
+		Color:      config.Format == Pretty && isTerminal(output),
+		Fields:     config.Fields,
+	}), nil
+}
+
+// handler wraps the given handler with the optional handlers enabled in the configuration.
+func handler(config Config, next slog.Handler) slog.Handler { //nolint:ireturn
+	if config.SpanEvents {
+		next = NewSpanEventHandler(next, config.SpanErrorStatus)
+	}
+
+	if config.Exceptions {
+		next = NewExceptionHandler(next)
+	}
+
+	if len(config.BaggageKeys) > 0 {
+		next = NewBaggageHandler(next, config.BaggageKeys...)
+	}
+
+	return next
+}
+
 // Shutdown gracefully shuts down the Logger, ensuring all logs are flushed.
 func (l *Logger) Shutdown(ctx context.Context) error {
 	var errs error
@@ -123,6 +174,10 @@ func (l *Logger) Shutdown(ctx context.Context) error {
 
 // ForceFlush forces the Logger to flush all buffered logs.
 func (l *Logger) ForceFlush(ctx context.Context) error {
+	if l.provider == nil {
+		return nil
+	}
+
 	if err := l.provider.ForceFlush(ctx); err != nil {
 		return fmt.Errorf("slogw force flush: %w", err)
 	}
@@ -3,6 +3,7 @@ package slogw
 import (
 	"context"
 	"io"
+	"log/slog"
 	"regexp"
 	"strings"
 	"testing"
@@ -69,6 +70,60 @@ func TestSlog(t *testing.T) {
 					`{"Timestamp":"` + test.RxTime + `","ObservedTimestamp":"` + test.RxTime + `","Severity":\d{1,2},"SeverityText":"(?i)\b(trace|debug|info|warn|error|fatal|panic)\d{0,2}\b","Body":{"Type":"String","Value":"test log"},"Attributes":\[\],"TraceID":"101112131415161718191a1b1c1d1e1f","SpanID":"2021222324252627","TraceFlags":"00","Resource":\[{"Key":"service\.name","Value":{"Type":"STRING","Value":"slogw"}},{"Key":"service\.version","Value":{"Type":"STRING","Value":"v\d+\.\d+\.\d+"}},{"Key":"telemetry\.sdk\.language","Value":{"Type":"STRING","Value":"go"}},{"Key":"telemetry\.sdk\.name","Value":{"Type":"STRING","Value":"opentelemetry"}},{"Key":"telemetry\.sdk\.version","Value":{"Type":"STRING","Value":"` + test.RxTelemetrySDKVersion + `"}}\],"Scope":{"Name":"slogw","Version":"","SchemaURL":"","Attributes":{}},"DroppedAttributes":0}`),
 			},
 		},
+		{
+			name: "logfmt",
+			args: args{
+				config: Config{
+					Enable: true,
+					Format: Logfmt,
+					Level:  "debug",
+				},
+				writers: []io.Writer{},
+				message: "test log",
+				traceID: trace.TraceID{
+					0x10, 0x11, 0x12, 0x13,
+					0x14, 0x15, 0x16, 0x17,
+					0x18, 0x19, 0x1a, 0x1b,
+					0x1c, 0x1d, 0x1e, 0x1f,
+				},
+				spanID: trace.SpanID{
+					0x20, 0x21, 0x22, 0x23,
+					0x24, 0x25, 0x26, 0x27,
+				},
+			},
+			want: want{
+				Type: &Logger{},
+				RxLog: regexp.MustCompile(
+					`^time=` + test.RxTime + ` level=(debug|info) trace_id=101112131415161718191a1b1c1d1e1f span_id=2021222324252627 msg="test log"\n$`),
+			},
+		},
+		{
+			name: "pretty",
+			args: args{
+				config: Config{
+					Enable: true,
+					Format: Pretty,
+					Level:  "debug",
+					Fields: []string{FieldLevel, FieldTrace, FieldMessage},
+				},
+				writers: []io.Writer{},
+				message: "test log",
+				traceID: trace.TraceID{
+					0x10, 0x11, 0x12, 0x13,
+					0x14, 0x15, 0x16, 0x17,
+					0x18, 0x19, 0x1a, 0x1b,
+					0x1c, 0x1d, 0x1e, 0x1f,
+				},
+				spanID: trace.SpanID{
+					0x20, 0x21, 0x22, 0x23,
+					0x24, 0x25, 0x26, 0x27,
+				},
+			},
+			want: want{
+				Type:  &Logger{},
+				RxLog: regexp.MustCompile(`^(DBG|INF) \[10111213\] test log\n$`),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -117,6 +172,20 @@ func TestSlog(t *testing.T) {
 	}
 }
 
+func TestConfigureZero(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	logger, err := Configure(ctx, Config{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, logger.Shutdown(ctx))
+
+	level, err := ParseLevel("")
+	require.NoError(t, err)
+	assert.Equal(t, slog.LevelInfo, level)
+}
+
 func removeColorFormatting(input string) string {
 	re := regexp.MustCompile(`\033\[[0-9;]*[mK]`)
 
@@ -0,0 +1,99 @@
+package slogw
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/yolkhovyy/go-otelw/otelw/tracew"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanEventHandler is a slog.Handler that mirrors log records onto the recording
+// span carried by the record context, before passing them to the next handler.
+// Each record becomes a span event named after the log message, with the record
+// attributes and its severity. Optionally, error-level records set the span status to Error.
+type SpanEventHandler struct {
+	next        slog.Handler
+	errorStatus bool
+	attrs       []attribute.KeyValue
+	group       string
+}
+
+// NewSpanEventHandler creates a SpanEventHandler wrapping the next handler.
+// If errorStatus is true, records at slog.LevelError or above mark the span status as Error.
+func NewSpanEventHandler(next slog.Handler, errorStatus bool) *SpanEventHandler {
+	return &SpanEventHandler{
+		next:        next,
+		errorStatus: errorStatus,
+	}
+}
+
+// Enabled reports whether the next handler handles records at the given level.
+func (h *SpanEventHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle adds the record as an event to the recording span in ctx, if any,
+// and passes the record to the next handler.
+func (h *SpanEventHandler) Handle(ctx context.Context, record slog.Record) error {
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		attrs := make([]attribute.KeyValue, 0, len(h.attrs)+record.NumAttrs()+1)
+		attrs = append(attrs, attribute.String("log.severity", record.Level.String()))
+		attrs = append(attrs, h.attrs...)
+
+		record.Attrs(func(attr slog.Attr) bool {
+			attrs = append(attrs, tracew.AttrKeyValues(h.group, attr)...)
+
+			return true
+		})
+
+		span.AddEvent(record.Message,
+			trace.WithTimestamp(record.Time),
+			trace.WithAttributes(attrs...),
+		)
+
+		if h.errorStatus && record.Level >= slog.LevelError {
+			span.SetStatus(codes.Error, record.Message)
+		}
+	}
+
+	if err := h.next.Handle(ctx, record); err != nil {
+		return fmt.Errorf("span event handler: %w", err)
+	}
+
+	return nil
+}
+
+// WithAttrs returns a new SpanEventHandler whose events include the given attributes.
+func (h *SpanEventHandler) WithAttrs(attrs []slog.Attr) slog.Handler { //nolint:ireturn
+	handler := *h
+	handler.next = h.next.WithAttrs(attrs)
+	handler.attrs = append([]attribute.KeyValue{}, h.attrs...)
+
+	for _, attr := range attrs {
+		handler.attrs = append(handler.attrs, tracew.AttrKeyValues(h.group, attr)...)
+	}
+
+	return &handler
+}
+
+// WithGroup returns a new SpanEventHandler that qualifies subsequent attributes with the group name.
+func (h *SpanEventHandler) WithGroup(name string) slog.Handler { //nolint:ireturn
+	if name == "" {
+		return h
+	}
+
+	handler := *h
+	handler.next = h.next.WithGroup(name)
+
+	if h.group == "" {
+		handler.group = name
+	} else {
+		handler.group = h.group + "." + name
+	}
+
+	return &handler
+}
@@ -0,0 +1,114 @@
+package slogw
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+//nolint:funlen
+func TestSpanEventHandler(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		errorStatus bool
+		level       slog.Level
+	}
+
+	type want struct {
+		status codes.Code
+		attrs  []attribute.KeyValue
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "info",
+			args: args{
+				errorStatus: true,
+				level:       slog.LevelInfo,
+			},
+			want: want{
+				status: codes.Unset,
+				attrs: []attribute.KeyValue{
+					attribute.String("log.severity", "INFO"),
+					attribute.String("service", "test"),
+					attribute.Int64("request.sequence", 42),
+					attribute.String("request.input", "foo"),
+				},
+			},
+		},
+		{
+			name: "error with status",
+			args: args{
+				errorStatus: true,
+				level:       slog.LevelError,
+			},
+			want: want{
+				status: codes.Error,
+				attrs: []attribute.KeyValue{
+					attribute.String("log.severity", "ERROR"),
+					attribute.String("service", "test"),
+					attribute.Int64("request.sequence", 42),
+					attribute.String("request.input", "foo"),
+				},
+			},
+		},
+		{
+			name: "error without status",
+			args: args{
+				errorStatus: false,
+				level:       slog.LevelError,
+			},
+			want: want{
+				status: codes.Unset,
+				attrs: []attribute.KeyValue{
+					attribute.String("log.severity", "ERROR"),
+					attribute.String("service", "test"),
+					attribute.Int64("request.sequence", 42),
+					attribute.String("request.input", "foo"),
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+			logger := slog.New(NewSpanEventHandler(
+				slog.NewTextHandler(io.Discard, nil), test.args.errorStatus,
+			)).With(slog.String("service", "test")).WithGroup("request")
+
+			ctx, span := provider.Tracer("slogw").Start(context.Background(), "test")
+			logger.Log(ctx, test.args.level, "test log",
+				slog.Int("sequence", 42),
+				slog.String("input", "foo"),
+			)
+			span.End()
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, test.want.status, spans[0].Status().Code)
+
+			events := spans[0].Events()
+			require.Len(t, events, 1)
+			assert.Equal(t, "test log", events[0].Name)
+			assert.Equal(t, test.want.attrs, events[0].Attributes)
+		})
+	}
+}
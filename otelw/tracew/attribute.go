@@ -0,0 +1,143 @@
+package tracew
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"reflect"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// attributes converts slog-style arguments, alternating keys and values or slog.Attr,
+// into OpenTelemetry attributes. Group attributes are flattened, their keys are joined with dots.
+func attributes(args ...any) []attribute.KeyValue {
+	var record slog.Record
+
+	record.Add(args...)
+
+	kvs := make([]attribute.KeyValue, 0, record.NumAttrs())
+
+	record.Attrs(func(attr slog.Attr) bool {
+		kvs = append(kvs, AttrKeyValues("", attr)...)
+
+		return true
+	})
+
+	return kvs
+}
+
+// AttrKeyValues converts a slog attribute into typed OpenTelemetry attributes, see Span.Set.
+// Group attributes are flattened, their keys are joined with dots and prefixed with the given prefix.
+// Empty attributes are ignored.
+func AttrKeyValues(prefix string, attr slog.Attr) []attribute.KeyValue {
+	if attr.Equal(slog.Attr{}) {
+		return nil
+	}
+
+	value := attr.Value.Resolve()
+
+	key := attr.Key
+	if prefix != "" && key != "" {
+		key = prefix + "." + key
+	} else if key == "" {
+		key = prefix
+	}
+
+	if value.Kind() == slog.KindGroup {
+		var kvs []attribute.KeyValue
+
+		for _, a := range value.Group() {
+			kvs = append(kvs, AttrKeyValues(key, a)...)
+		}
+
+		return kvs
+	}
+
+	if key == "" {
+		return nil
+	}
+
+	return []attribute.KeyValue{keyValue(key, value.Any())}
+}
+
+// keyValue converts a value into a typed OpenTelemetry attribute.
+// Integers, floats, booleans and their slices keep their type, durations and times
+// are formatted, errors and fmt.Stringers use their string form, other slices
+// become string slices and any other value is formatted with fmt.Sprint.
+//
+//nolint:cyclop
+func keyValue(key string, value any) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int8:
+		return attribute.Int64(key, int64(v))
+	case int16:
+		return attribute.Int64(key, int64(v))
+	case int32:
+		return attribute.Int64(key, int64(v))
+	case int64:
+		return attribute.Int64(key, v)
+	case uint8:
+		return attribute.Int64(key, int64(v))
+	case uint16:
+		return attribute.Int64(key, int64(v))
+	case uint32:
+		return attribute.Int64(key, int64(v))
+	case uint:
+		return uintKeyValue(key, uint64(v))
+	case uint64:
+		return uintKeyValue(key, v)
+	case float32:
+		return attribute.Float64(key, float64(v))
+	case float64:
+		return attribute.Float64(key, v)
+	case time.Duration:
+		return attribute.String(key, v.String())
+	case time.Time:
+		return attribute.String(key, v.Format(time.RFC3339Nano))
+	case []string:
+		return attribute.StringSlice(key, v)
+	case []bool:
+		return attribute.BoolSlice(key, v)
+	case []int:
+		return attribute.IntSlice(key, v)
+	case []int64:
+		return attribute.Int64Slice(key, v)
+	case []float64:
+		return attribute.Float64Slice(key, v)
+	case error:
+		return attribute.String(key, v.Error())
+	case fmt.Stringer:
+		return attribute.String(key, v.String())
+	case nil:
+		return attribute.String(key, "<nil>")
+	}
+
+	if reflected := reflect.ValueOf(value); reflected.Kind() == reflect.Slice || reflected.Kind() == reflect.Array {
+		strs := make([]string, reflected.Len())
+		for i := range strs {
+			strs[i] = fmt.Sprint(reflected.Index(i).Interface())
+		}
+
+		return attribute.StringSlice(key, strs)
+	}
+
+	return attribute.String(key, fmt.Sprint(value))
+}
+
+// uintKeyValue converts an unsigned integer into an Int64 attribute,
+// or a string attribute if it overflows int64.
+func uintKeyValue(key string, value uint64) attribute.KeyValue {
+	if value <= math.MaxInt64 {
+		return attribute.Int64(key, int64(value))
+	}
+
+	return attribute.String(key, fmt.Sprint(value))
+}
@@ -0,0 +1,108 @@
+package tracew
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+//nolint:funlen
+func TestKeyValue(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		value any
+	}
+
+	type want struct {
+		kv attribute.KeyValue
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{name: "string", args: args{value: "v"}, want: want{kv: attribute.String("key", "v")}},
+		{name: "bool", args: args{value: true}, want: want{kv: attribute.Bool("key", true)}},
+		{name: "int", args: args{value: 42}, want: want{kv: attribute.Int("key", 42)}},
+		{name: "int32", args: args{value: int32(42)}, want: want{kv: attribute.Int64("key", 42)}},
+		{name: "uint16", args: args{value: uint16(42)}, want: want{kv: attribute.Int64("key", 42)}},
+		{name: "uint64", args: args{value: uint64(42)}, want: want{kv: attribute.Int64("key", 42)}},
+		{
+			name: "uint64 overflow",
+			args: args{value: uint64(math.MaxUint64)},
+			want: want{kv: attribute.String("key", "18446744073709551615")},
+		},
+		{name: "float32", args: args{value: float32(1.5)}, want: want{kv: attribute.Float64("key", 1.5)}},
+		{name: "duration", args: args{value: 1500 * time.Millisecond}, want: want{kv: attribute.String("key", "1.5s")}},
+		{
+			name: "time",
+			args: args{value: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+			want: want{kv: attribute.String("key", "2025-01-02T03:04:05Z")},
+		},
+		{name: "error", args: args{value: errTest}, want: want{kv: attribute.String("key", "test error")}},
+		{name: "stringer", args: args{value: net.IPv4(127, 0, 0, 1)}, want: want{kv: attribute.String("key", "127.0.0.1")}},
+		{
+			name: "string slice",
+			args: args{value: []string{"a", "b"}},
+			want: want{kv: attribute.StringSlice("key", []string{"a", "b"})},
+		},
+		{name: "int slice", args: args{value: []int{1, 2}}, want: want{kv: attribute.IntSlice("key", []int{1, 2})}},
+		{
+			name: "duration slice",
+			args: args{value: []time.Duration{time.Second, time.Minute}},
+			want: want{kv: attribute.StringSlice("key", []string{"1s", "1m0s"})},
+		},
+		{name: "nil", args: args{value: nil}, want: want{kv: attribute.String("key", "<nil>")}},
+		{name: "struct", args: args{value: struct{ A int }{A: 1}}, want: want{kv: attribute.String("key", "{1}")}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.want.kv, keyValue("key", test.args.value))
+		})
+	}
+}
+
+func TestAttributes(t *testing.T) {
+	t.Parallel()
+
+	kvs := attributes(
+		"sequence", 1,
+		slog.String("input", "hello"),
+		slog.Group("http", slog.Int("status", 404), slog.Duration("elapsed", time.Second)),
+		"dangling",
+	)
+
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Int64("sequence", 1),
+		attribute.String("input", "hello"),
+		attribute.Int64("http.status", 404),
+		attribute.String("http.elapsed", "1s"),
+		attribute.String("!BADKEY", "dangling"),
+	}, kvs)
+}
+
+func TestAttrKeyValues(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Int64("request.http.status", 404),
+		attribute.IntSlice("request.http.retries", []int{1, 2}),
+	}, AttrKeyValues("request", slog.Group("http",
+		slog.Int("status", 404),
+		slog.Any("retries", []int{1, 2}),
+	)))
+
+	assert.Equal(t, []attribute.KeyValue{attribute.String("request", "hello")},
+		AttrKeyValues("request", slog.String("", "hello")))
+	assert.Empty(t, AttrKeyValues("request", slog.Attr{}))
+}
@@ -0,0 +1,115 @@
+package tracew
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yolkhovyy/go-otelw/otelw/validation"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SetBaggage returns a copy of ctx whose baggage has the member key set to value.
+// The value is stored as is and percent-encoded when propagated.
+// It returns an error if the key is not a valid W3C baggage key, the value is not valid UTF-8
+// or the baggage exceeds its limits.
+func SetBaggage(ctx context.Context, key, value string) (context.Context, error) {
+	if err := validateBaggageKey(key); err != nil {
+		return ctx, fmt.Errorf("tracew set baggage: %w", err)
+	}
+
+	member, err := baggage.NewMemberRaw(key, value)
+	if err != nil {
+		return ctx, fmt.Errorf("tracew set baggage: %w", err)
+	}
+
+	bag, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		return ctx, fmt.Errorf("tracew set baggage: %w", err)
+	}
+
+	// Validate the limits of the number of members and the size of the baggage.
+	if _, err := baggage.New(bag.Members()...); err != nil {
+		return ctx, fmt.Errorf("tracew set baggage: %w", err)
+	}
+
+	return baggage.ContextWithBaggage(ctx, bag), nil
+}
+
+// GetBaggage returns the value of the baggage member key of ctx,
+// and whether the member exists.
+func GetBaggage(ctx context.Context, key string) (string, bool) {
+	member := baggage.FromContext(ctx).Member(key)
+
+	return member.Value(), member.Key() != ""
+}
+
+// DeleteBaggage returns a copy of ctx whose baggage has no member key.
+func DeleteBaggage(ctx context.Context, key string) context.Context {
+	return baggage.ContextWithBaggage(ctx, baggage.FromContext(ctx).DeleteMember(key))
+}
+
+// BaggageAttributes returns the baggage members of ctx with the given keys as string attributes,
+// missing members are omitted.
+func BaggageAttributes(ctx context.Context, keys ...string) []attribute.KeyValue {
+	bag := baggage.FromContext(ctx)
+
+	attrs := make([]attribute.KeyValue, 0, len(keys))
+
+	for _, key := range keys {
+		if member := bag.Member(key); member.Key() != "" {
+			attrs = append(attrs, attribute.String(key, member.Value()))
+		}
+	}
+
+	return attrs
+}
+
+// BaggageSpanProcessor is an sdktrace.SpanProcessor that copies selected baggage members
+// of the parent context onto every started span as attributes, e.g. tenant.id.
+type BaggageSpanProcessor struct {
+	keys []string
+}
+
+var _ sdktrace.SpanProcessor = (*BaggageSpanProcessor)(nil)
+
+// NewBaggageSpanProcessor creates a BaggageSpanProcessor copying the baggage members with the given keys.
+func NewBaggageSpanProcessor(keys ...string) *BaggageSpanProcessor {
+	return &BaggageSpanProcessor{keys: keys}
+}
+
+// OnStart sets the selected baggage members of the parent context as span attributes.
+func (p *BaggageSpanProcessor) OnStart(parent context.Context, span sdktrace.ReadWriteSpan) {
+	span.SetAttributes(BaggageAttributes(parent, p.keys...)...)
+}
+
+// OnEnd does nothing.
+func (p *BaggageSpanProcessor) OnEnd(sdktrace.ReadOnlySpan) {}
+
+// Shutdown does nothing.
+func (p *BaggageSpanProcessor) Shutdown(context.Context) error { return nil }
+
+// ForceFlush does nothing.
+func (p *BaggageSpanProcessor) ForceFlush(context.Context) error { return nil }
+
+// validateBaggageKey checks that the key is a valid W3C baggage key, a non-empty token.
+func validateBaggageKey(key string) error {
+	if _, err := baggage.NewMember(key, ""); err != nil {
+		return fmt.Errorf("%w %q", ErrInvalidBaggageKey, key)
+	}
+
+	return nil
+}
+
+// ValidateBaggageKeys checks that the keys of a BaggageKeys configuration field are valid W3C baggage keys.
+// It returns an ErrInvalidBaggageKey error for each invalid key, qualified by its path, e.g. BaggageKeys[1].
+func ValidateBaggageKeys(keys []string) []error {
+	var errs []error
+
+	for i, key := range keys {
+		errs = append(errs, validation.Field(fmt.Sprintf("BaggageKeys[%d]", i), validateBaggageKey(key)))
+	}
+
+	return errs
+}
@@ -0,0 +1,117 @@
+package tracew
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+//nolint:funlen
+func TestSetBaggage(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		key   string
+		value string
+	}
+
+	type want struct {
+		fails bool
+		err   error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "valid",
+			args: args{key: "tenant.id", value: "acme corp"},
+		},
+		{
+			name: "empty value",
+			args: args{key: "tenant.id"},
+		},
+		{
+			name: "empty key",
+			args: args{value: "acme"},
+			want: want{fails: true, err: ErrInvalidBaggageKey},
+		},
+		{
+			name: "invalid key",
+			args: args{key: "tenant id", value: "acme"},
+			want: want{fails: true, err: ErrInvalidBaggageKey},
+		},
+		{
+			name: "invalid value",
+			args: args{key: "tenant.id", value: string([]byte{0xff})},
+			want: want{fails: true},
+		},
+		{
+			name: "too large",
+			args: args{key: "tenant.id", value: strings.Repeat("a", 8192)},
+			want: want{fails: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, err := SetBaggage(context.Background(), test.args.key, test.args.value)
+
+			if test.want.fails {
+				require.Error(t, err)
+
+				if test.want.err != nil {
+					require.ErrorIs(t, err, test.want.err)
+				}
+
+				_, ok := GetBaggage(ctx, test.args.key)
+				assert.False(t, ok)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			value, ok := GetBaggage(ctx, test.args.key)
+			assert.True(t, ok)
+			assert.Equal(t, test.args.value, value)
+
+			_, ok = GetBaggage(DeleteBaggage(ctx, test.args.key), test.args.key)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestBaggageSpanProcessor(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewBaggageSpanProcessor("tenant.id", "request.origin")),
+		sdktrace.WithSpanProcessor(recorder),
+	)
+
+	ctx, err := SetBaggage(context.Background(), "tenant.id", "acme")
+	require.NoError(t, err)
+
+	ctx, err = SetBaggage(ctx, "user.id", "42")
+	require.NoError(t, err)
+
+	_, span := provider.Tracer("test").Start(ctx, "span")
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("tenant.id", "acme")}, spans[0].Attributes())
+	require.NoError(t, provider.Shutdown(ctx))
+}
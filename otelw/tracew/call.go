@@ -0,0 +1,132 @@
+package tracew
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// errorTypeKey is the semantic convention attribute of the error type of a failed call.
+const errorTypeKey = attribute.Key("error.type")
+
+// CallOption configures a traced call of Do or Call.
+type CallOption func(*callConfig)
+
+// callConfig holds the options of a traced call.
+type callConfig struct {
+	provider     trace.TracerProvider
+	startOptions []trace.SpanStartOption
+	duration     metric.Float64Histogram
+	panicAsError bool
+}
+
+// WithStartOptions sets the span start options, e.g. trace.WithAttributes, of a traced call.
+func WithStartOptions(options ...trace.SpanStartOption) CallOption {
+	return func(c *callConfig) {
+		c.startOptions = append(c.startOptions, options...)
+	}
+}
+
+// WithTracerProvider sets the tracer provider of a traced call, by default the global tracer provider.
+func WithTracerProvider(provider trace.TracerProvider) CallOption {
+	return func(c *callConfig) {
+		c.provider = provider
+	}
+}
+
+// WithDuration records the duration of a traced call, in seconds, into the histogram.
+// Failed calls are recorded with the error.type attribute.
+func WithDuration(histogram metric.Float64Histogram) CallOption {
+	return func(c *callConfig) {
+		c.duration = histogram
+	}
+}
+
+// WithPanicAsError converts a panic of a traced call into the returned error instead of propagating it.
+// It is intended for goroutine workers, where an unrecovered panic crashes the process.
+func WithPanicAsError() CallOption {
+	return func(c *callConfig) {
+		c.panicAsError = true
+	}
+}
+
+// Do runs fn within a new span named sname of the tracer tname.
+// The span records the error returned by fn and its status is set accordingly.
+// If fn panics, the panic is recorded on the span, the span is ended and flushed,
+// and the panic is propagated, or returned as an error with WithPanicAsError.
+//
+// Usage:
+//
+//	err := tracew.Do(ctx, "echo", "worker", func(ctx context.Context) error {
+//		return work(ctx)
+//	})
+func Do(
+	ctx context.Context,
+	tname, sname string,
+	fn func(ctx context.Context) error,
+	options ...CallOption,
+) error {
+	_, err := Call(ctx, tname, sname, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	}, options...)
+
+	return err
+}
+
+// Call runs fn within a new span named sname of the tracer tname and returns its result.
+// The span records the error returned by fn and its status is set accordingly.
+// If fn panics, the panic is recorded on the span, the span is ended and flushed,
+// and the panic is propagated, or returned as an error with WithPanicAsError.
+func Call[T any](
+	ctx context.Context,
+	tname, sname string,
+	fn func(ctx context.Context) (T, error),
+	options ...CallOption,
+) (result T, err error) {
+	var config callConfig
+	for _, option := range options {
+		option(&config)
+	}
+
+	ctx, span := config.start(ctx, tname, sname)
+	start := time.Now()
+
+	defer func() { config.record(ctx, start, err) }()
+
+	if config.panicAsError {
+		defer span.EndRecoverAsError(&err)
+	} else {
+		defer span.EndRecover(&err)
+	}
+
+	return fn(ctx)
+}
+
+// start starts the span of a traced call with the configured tracer provider.
+func (c *callConfig) start(ctx context.Context, tname, sname string) (context.Context, Span) {
+	if c.provider == nil {
+		return Start(ctx, tname, sname, c.startOptions...)
+	}
+
+	ctx, span := c.provider.Tracer(tname).Start(ctx, sname, c.startOptions...) //nolint:spancheck
+
+	return ctx, Span{Span: span} //nolint:spancheck
+}
+
+// record records the call duration if a duration histogram is configured.
+func (c *callConfig) record(ctx context.Context, start time.Time, err error) {
+	if c.duration == nil {
+		return
+	}
+
+	var options []metric.RecordOption
+	if err != nil {
+		options = append(options, metric.WithAttributes(errorTypeKey.String(fmt.Sprintf("%T", err))))
+	}
+
+	c.duration.Record(ctx, time.Since(start).Seconds(), options...)
+}
@@ -0,0 +1,182 @@
+package tracew
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var errTest = errors.New("test error")
+
+// newRecorder returns a tracer provider of a single test, recording the ended spans.
+func newRecorder() (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), recorder
+}
+
+//nolint:funlen
+func TestCall(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		fn      func(ctx context.Context) (int, error)
+		options []CallOption
+	}
+
+	type want struct {
+		result    int
+		err       error
+		panics    bool
+		status    codes.Code
+		events    int
+		errorType string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "ok",
+			args: args{
+				fn: func(ctx context.Context) (int, error) {
+					if !trace.SpanContextFromContext(ctx).IsValid() {
+						return 0, errTest
+					}
+
+					return 42, nil
+				},
+			},
+			want: want{
+				result: 42,
+				status: codes.Ok,
+			},
+		},
+		{
+			name: "error",
+			args: args{
+				fn: func(context.Context) (int, error) {
+					return 0, errTest
+				},
+			},
+			want: want{
+				err:       errTest,
+				status:    codes.Error,
+				events:    1,
+				errorType: "*errors.errorString",
+			},
+		},
+		{
+			name: "panic",
+			args: args{
+				fn: func(context.Context) (int, error) {
+					panic("test panic")
+				},
+			},
+			want: want{
+				panics:    true,
+				status:    codes.Error,
+				events:    1,
+				errorType: "*fmt.wrapError",
+			},
+		},
+		{
+			name: "panic as error",
+			args: args{
+				fn: func(context.Context) (int, error) {
+					panic("test panic")
+				},
+				options: []CallOption{WithPanicAsError()},
+			},
+			want: want{
+				err:       ErrPanic,
+				status:    codes.Error,
+				events:    1,
+				errorType: "*fmt.wrapError",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			provider, recorder := newRecorder()
+
+			reader := sdkmetric.NewManualReader()
+			histogram, err := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).
+				Meter("test").Float64Histogram("test.duration")
+			require.NoError(t, err)
+
+			call := func() (int, error) {
+				return Call(ctx, "test/call", "call", test.args.fn, append(test.args.options,
+					WithTracerProvider(provider),
+					WithStartOptions(trace.WithAttributes(attribute.String("test", test.name))),
+					WithDuration(histogram),
+				)...)
+			}
+
+			if test.want.panics {
+				assert.PanicsWithValue(t, "test panic", func() { _, _ = call() })
+			} else {
+				result, err := call()
+				if test.want.err != nil {
+					require.ErrorIs(t, err, test.want.err)
+				} else {
+					require.NoError(t, err)
+				}
+
+				assert.Equal(t, test.want.result, result)
+			}
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "call", spans[0].Name())
+			assert.Equal(t, test.want.status, spans[0].Status().Code)
+			assert.Len(t, spans[0].Events(), test.want.events)
+			assert.Contains(t, spans[0].Attributes(), attribute.String("test", test.name))
+
+			var resourceMetrics metricdata.ResourceMetrics
+
+			require.NoError(t, reader.Collect(ctx, &resourceMetrics))
+			require.Len(t, resourceMetrics.ScopeMetrics, 1)
+
+			data, ok := resourceMetrics.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64])
+			require.True(t, ok)
+			require.Len(t, data.DataPoints, 1)
+			assert.Equal(t, uint64(1), data.DataPoints[0].Count)
+
+			errorType, _ := data.DataPoints[0].Attributes.Value(errorTypeKey)
+			assert.Equal(t, test.want.errorType, errorType.AsString())
+		})
+	}
+}
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+
+	provider, recorder := newRecorder()
+
+	err := Do(context.Background(), "test/do", "do", func(context.Context) error {
+		return errTest
+	}, WithTracerProvider(provider))
+	require.ErrorIs(t, err, errTest)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, errTest.Error(), spans[0].Status().Description)
+}
@@ -1,12 +1,25 @@
 package tracew
 
-import "github.com/yolkhovyy/go-otelw/otelw/otlp"
+import (
+	"github.com/yolkhovyy/go-otelw/otelw/otlp"
+	"github.com/yolkhovyy/go-otelw/otelw/validation"
+)
 
 // Config holds the configuration settings for the tracew package.
 type Config struct {
 	// Enable indicates whether tracings is enabled.
 	Enable bool `json:"enable" yaml:"enable" mapstructure:"enable"`
 
+	// IDGenerator selects the trace ID generator - random (default), xray.
+	IDGenerator IDGenerator `json:"id_generator" yaml:"idGenerator" mapstructure:"IDGenerator"`
+
+	// EnvParent specifies whether to extract a parent span context and baggage from the TRACEPARENT,
+	// TRACESTATE and BAGGAGE environment variables, see Tracer.ParentContext.
+	EnvParent bool `json:"env_parent" yaml:"envParent" mapstructure:"EnvParent"`
+
+	// BaggageKeys lists the baggage members copied onto every span as attributes, e.g. tenant.id.
+	BaggageKeys []string `json:"baggage_keys" yaml:"baggageKeys" mapstructure:"BaggageKeys"`
+
 	// OTLP holds the configuration for the OTEL protocol.
 	OTLP otlp.Config `json:"otlp" yaml:"otlp" mapstructure:"otlp"`
 }
@@ -18,6 +31,8 @@ func Defaults() map[string]any {
 	defaults := make(map[string]any)
 
 	defaults["Enable"] = DefaultEnable
+	defaults["IDGenerator"] = DefaultIDGenerator
+	defaults["EnvParent"] = DefaultEnvParent
 
 	for k, v := range otlp.Defaults() {
 		defaults["OTLP."+k] = v
@@ -26,5 +41,37 @@ func Defaults() map[string]any {
 	return defaults
 }
 
-// DefaultEnable defines whether tracing is enabled by default.
-const DefaultEnable = false
+// Validate checks the configuration and returns all problems found,
+// qualified with the configuration field paths, or nil if there are none.
+// The OTLP configuration is validated only if tracing is enabled.
+func (c Config) Validate() error {
+	return c.validate(true)
+}
+
+// validate checks the configuration, the OTLP configuration is checked only if withOTLP is true.
+func (c Config) validate(withOTLP bool) error {
+	var errs []error
+
+	if _, err := ParseIDGenerator(string(c.IDGenerator)); err != nil {
+		errs = append(errs, validation.Field("IDGenerator", err))
+	}
+
+	errs = append(errs, ValidateBaggageKeys(c.BaggageKeys)...)
+
+	if withOTLP && c.Enable {
+		errs = append(errs, validation.Join("OTLP", c.OTLP.Validate()))
+	}
+
+	return validation.Join("", errs...)
+}
+
+const (
+	// DefaultEnable defines whether tracing is enabled by default.
+	DefaultEnable = false
+
+	// DefaultIDGenerator is the default trace ID generator.
+	DefaultIDGenerator = IDGeneratorRandom
+
+	// DefaultEnvParent defines whether a parent context is extracted from the environment by default.
+	DefaultEnvParent = false
+)
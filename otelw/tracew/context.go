@@ -0,0 +1,21 @@
+package tracew
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Detach returns a new context carrying the span and baggage of ctx, but not its
+// cancellation, deadline or other values. It is intended for background work
+// that must outlive the request it was started from, while staying in its trace:
+//
+//	go job(tracew.Detach(ctx))
+//
+// Use context.WithoutCancel to keep all values of ctx.
+func Detach(ctx context.Context) context.Context {
+	detached := trace.ContextWithSpan(context.Background(), trace.SpanFromContext(ctx))
+
+	return baggage.ContextWithBaggage(detached, baggage.FromContext(ctx))
+}
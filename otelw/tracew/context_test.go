@@ -0,0 +1,46 @@
+package tracew
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type contextKey struct{}
+
+func TestDetach(t *testing.T) {
+	t.Parallel()
+
+	member, err := baggage.NewMember("tenant.id", "acme")
+	require.NoError(t, err)
+
+	bag, err := baggage.New(member)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	ctx = baggage.ContextWithBaggage(ctx, bag)
+	ctx = context.WithValue(ctx, contextKey{}, "value")
+
+	provider, _ := newRecorder()
+
+	ctx, span := provider.Tracer("test/detach").Start(ctx, "request")
+	defer span.End()
+
+	detached := Detach(ctx)
+
+	cancel()
+
+	require.Error(t, ctx.Err())
+	require.NoError(t, detached.Err())
+
+	_, hasDeadline := detached.Deadline()
+	assert.False(t, hasDeadline)
+	assert.Nil(t, detached.Value(contextKey{}))
+	assert.Equal(t, span.SpanContext(), trace.SpanContextFromContext(detached))
+	assert.Equal(t, "acme", baggage.FromContext(detached).Member("tenant.id").Value())
+}
@@ -21,4 +21,46 @@
 //		trace.WithAttributes(attribute.Int("sequence", sequence)),
 //	)
 //	defer func() { span.End(err) }()
+//
+// EndRecover also records a panic on the span, ends and flushes it and re-panics,
+// EndRecoverAsError returns the panic as an error instead:
+//
+//	defer span.EndRecover(&err)
+//
+// Event, Set and Link add events, typed attributes and links to a span,
+// EndWithStatus ends it with a status other than error, e.g. for client errors:
+//
+//	span.Event("do echo", "sequence", sequence, slog.String("input", input))
+//	span.Set("elapsed", time.Since(start))
+//	span.EndWithStatus(codes.Unset, "not found")
+//
+// Detach keeps the span and baggage of a context for background work that outlives it,
+// Group runs traced goroutines and joins their errors:
+//
+//	group, ctx := tracew.NewGroup(ctx, "echo")
+//	group.Go("worker", work)
+//	err := group.Wait()
+//
+// SetBaggage and GetBaggage set and read validated baggage members,
+// Config.BaggageKeys copies selected members onto every span:
+//
+//	ctx, err := tracew.SetBaggage(ctx, "tenant.id", tenantID)
+//
+// InjectEnv propagates the trace to a child process through environment variables,
+// Config.EnvParent and Tracer.ParentContext continue it in the child:
+//
+//	tracew.InjectEnv(ctx, cmd)
+//
+// Do and Call reduce the instrumentation of a function to one line,
+// recording errors and panics and optionally the call duration:
+//
+//	result, err := tracew.Call(ctx, "echo", "worker", work,
+//		tracew.WithDuration(histogram),
+//	)
+//
+// WithTracerProvider starts the span of a call with a tracer provider other than
+// the global one, e.g. one recording the spans of a test:
+//
+//	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(tracetest.NewSpanRecorder()))
+//	err := tracew.Do(ctx, "echo", "worker", work, tracew.WithTracerProvider(provider))
 package tracew
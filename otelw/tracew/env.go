@@ -0,0 +1,135 @@
+package tracew
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// cardinalityLimitEnv is the environment variable of the SDK cardinality limit,
+// set process-wide by metricw and not inherited by child processes.
+const cardinalityLimitEnv = "OTEL_GO_X_CARDINALITY_LIMIT"
+
+// EnvCarrier is a propagation.TextMapCarrier of environment variables in the "KEY=value" form
+// of os.Environ and exec.Cmd.Env. Propagation keys are mapped to upper-case variable names,
+// with dashes replaced by underscores, e.g. traceparent to TRACEPARENT.
+type EnvCarrier []string
+
+var _ propagation.TextMapCarrier = (*EnvCarrier)(nil)
+
+// Get returns the value of the variable of the key, or an empty string if it is not set.
+// The last entry wins, as in exec.Cmd.
+func (c *EnvCarrier) Get(key string) string {
+	prefix := envName(key) + "="
+
+	for i := len(*c) - 1; i >= 0; i-- {
+		if value, found := strings.CutPrefix((*c)[i], prefix); found {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// Set sets the variable of the key, replacing its existing entries.
+func (c *EnvCarrier) Set(key, value string) {
+	c.delete(key)
+
+	*c = append(*c, envName(key)+"="+value)
+}
+
+// Keys returns the lower-case keys of the variables.
+func (c *EnvCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c))
+
+	for _, entry := range *c {
+		if name, _, found := strings.Cut(entry, "="); found {
+			keys = append(keys, strings.ToLower(name))
+		}
+	}
+
+	return keys
+}
+
+// delete removes the entries of the variable of the key.
+func (c *EnvCarrier) delete(key string) {
+	prefix := envName(key) + "="
+
+	*c = slices.DeleteFunc(*c, func(entry string) bool {
+		return strings.HasPrefix(entry, prefix)
+	})
+}
+
+// envName returns the environment variable name of a propagation key.
+func envName(key string) string {
+	return strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
+
+// InjectEnv injects the span context and baggage of ctx with the global propagator
+// into the environment of cmd, e.g. the TRACEPARENT, TRACESTATE and BAGGAGE variables,
+// so that the child process continues the trace. A nil cmd.Env is initialized from
+// the environment of the current process, whose propagation variables are replaced
+// and whose OTEL_GO_X_CARDINALITY_LIMIT variable, set process-wide by metricw, is dropped.
+//
+// Usage:
+//
+//	cmd := exec.CommandContext(ctx, "batch", "--input", input)
+//	tracew.InjectEnv(ctx, cmd)
+//	err := cmd.Run()
+func InjectEnv(ctx context.Context, cmd *exec.Cmd) {
+	inherited := cmd.Env == nil
+	if inherited {
+		cmd.Env = os.Environ()
+	}
+
+	propagator := otel.GetTextMapPropagator()
+	carrier := EnvCarrier(cmd.Env)
+
+	if inherited {
+		carrier.delete(cardinalityLimitEnv)
+	}
+
+	for _, field := range propagator.Fields() {
+		carrier.delete(field)
+	}
+
+	propagator.Inject(ctx, &carrier)
+
+	cmd.Env = carrier
+}
+
+// ExtractEnv returns a copy of ctx carrying the remote span context and baggage extracted
+// with the global propagator from the environment of the current process, set by a parent
+// process with InjectEnv. See Config.EnvParent to extract them in Configure.
+func ExtractEnv(ctx context.Context) context.Context {
+	carrier := EnvCarrier(os.Environ())
+
+	return otel.GetTextMapPropagator().Extract(ctx, &carrier)
+}
+
+// ParentContext returns a copy of ctx carrying the remote span context and baggage
+// extracted from the process environment in Configure, if Config.EnvParent is set,
+// so that the spans of a CLI entry point continue the trace of its caller.
+// Otherwise ctx is returned unchanged.
+//
+// Usage:
+//
+//	ctx = tracer.ParentContext(ctx)
+func (t *Tracer) ParentContext(ctx context.Context) context.Context {
+	if t.parent.IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, t.parent)
+	}
+
+	if t.baggage.Len() > 0 {
+		ctx = baggage.ContextWithBaggage(ctx, t.baggage)
+	}
+
+	return ctx
+}
@@ -0,0 +1,119 @@
+package tracew
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var propagatorOnce sync.Once
+
+// setupPropagator sets the global trace context and baggage propagator, as Configure does.
+func setupPropagator() {
+	propagatorOnce.Do(func() {
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+			propagation.TraceContext{},
+			propagation.Baggage{},
+		))
+	})
+}
+
+func TestEnvCarrier(t *testing.T) {
+	t.Parallel()
+
+	carrier := EnvCarrier{"PATH=/bin", "TRACEPARENT=stale", "TRACEPARENT=old"}
+
+	assert.Equal(t, "old", carrier.Get("traceparent"))
+	assert.Empty(t, carrier.Get("tracestate"))
+
+	carrier.Set("traceparent", "new")
+	carrier.Set("x-amzn-trace-id", "Root=1")
+
+	assert.Equal(t, EnvCarrier{"PATH=/bin", "TRACEPARENT=new", "X_AMZN_TRACE_ID=Root=1"}, carrier)
+	assert.Equal(t, "Root=1", carrier.Get("x-amzn-trace-id"))
+	assert.Equal(t, []string{"path", "traceparent", "x_amzn_trace_id"}, carrier.Keys())
+}
+
+func TestInjectEnv(t *testing.T) {
+	t.Parallel()
+	setupPropagator()
+
+	member, err := baggage.NewMember("tenant.id", "acme")
+	require.NoError(t, err)
+
+	bag, err := baggage.New(member)
+	require.NoError(t, err)
+
+	provider, _ := newRecorder()
+
+	ctx, span := provider.Tracer("test/env").Start(baggage.ContextWithBaggage(context.Background(), bag), "parent")
+	defer span.End()
+
+	cmd := exec.Command("true")
+	cmd.Env = []string{"PATH=/bin", "TRACEPARENT=stale", "TRACESTATE=stale"}
+
+	InjectEnv(ctx, cmd)
+
+	assert.Equal(t, []string{
+		"PATH=/bin",
+		"TRACEPARENT=00-" + span.SpanContext().TraceID().String() + "-" + span.SpanContext().SpanID().String() + "-01",
+		"BAGGAGE=tenant.id=acme",
+	}, cmd.Env)
+}
+
+// TestInjectEnvCardinalityLimit is not parallel, it sets the process environment.
+func TestInjectEnvCardinalityLimit(t *testing.T) {
+	setupPropagator()
+
+	t.Setenv(cardinalityLimitEnv, "42")
+	t.Setenv("OTELW_TEST", "inherited")
+
+	cmd := exec.Command("true")
+	InjectEnv(context.Background(), cmd)
+
+	assert.Contains(t, cmd.Env, "OTELW_TEST=inherited")
+	assert.NotContains(t, cmd.Env, cardinalityLimitEnv+"=42")
+
+	cmd = exec.Command("true")
+	cmd.Env = []string{cardinalityLimitEnv + "=42"}
+	InjectEnv(context.Background(), cmd)
+
+	assert.Equal(t, []string{cardinalityLimitEnv + "=42"}, cmd.Env)
+}
+
+// TestExtractEnv is not parallel, it sets the process environment.
+func TestExtractEnv(t *testing.T) {
+	setupPropagator()
+
+	t.Setenv("TRACEPARENT", "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01")
+	t.Setenv("BAGGAGE", "tenant.id=acme")
+
+	ctx := ExtractEnv(context.Background())
+
+	spanContext := trace.SpanContextFromContext(ctx)
+	assert.True(t, spanContext.IsRemote())
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", spanContext.TraceID().String())
+	assert.Equal(t, "acme", baggage.FromContext(ctx).Member("tenant.id").Value())
+
+	tracer := &Tracer{
+		parent:  spanContext,
+		baggage: baggage.FromContext(ctx),
+	}
+
+	provider, _ := newRecorder()
+
+	ctx, span := provider.Tracer("test/env").Start(tracer.ParentContext(context.Background()), "child")
+	span.End()
+
+	assert.Equal(t, spanContext.TraceID(), span.SpanContext().TraceID())
+	assert.Equal(t, "acme", baggage.FromContext(ctx).Member("tenant.id").Value())
+	assert.Equal(t, context.Background(), (&Tracer{}).ParentContext(context.Background()))
+}
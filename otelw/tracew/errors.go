@@ -1,6 +1,20 @@
 package tracew
 
-import "errors"
+import (
+	"errors"
 
-// ErrInvalidProtocol is returned when config.Protocol is not equal to otlp.GRPC or otlp.HTTP.
-var ErrInvalidProtocol = errors.New("invalid protocol")
+	"github.com/yolkhovyy/go-otelw/otelw/otlp"
+)
+
+// ErrInvalidProtocol is returned when config.OTLP.Protocol is not equal to otlp.GRPC or otlp.HTTP.
+// It is otlp.ErrInvalidProtocol.
+var ErrInvalidProtocol = otlp.ErrInvalidProtocol
+
+// ErrPanic is recorded on a span when a traced call panics.
+var ErrPanic = errors.New("panic")
+
+// ErrInvalidBaggageKey is returned when config.BaggageKeys contains an invalid baggage key.
+var ErrInvalidBaggageKey = errors.New("invalid baggage key")
+
+// ErrInvalidIDGenerator is returned when config.IDGenerator is not equal to tracew.IDGeneratorRandom or tracew.IDGeneratorXRay.
+var ErrInvalidIDGenerator = errors.New("invalid id generator")
@@ -0,0 +1,113 @@
+package tracew
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// GroupOption configures a Group.
+type GroupOption func(*Group)
+
+// WithLinkedSpans makes the spans of a group new root spans linked to the span
+// of the group context, instead of its children. It is intended for large or
+// long-running fan-outs, which would otherwise bloat the parent trace.
+func WithLinkedSpans() GroupOption {
+	return func(g *Group) {
+		g.linked = true
+	}
+}
+
+// WithFailFast cancels the group context on the first error, like errgroup.
+// By default all functions run to completion.
+func WithFailFast() GroupOption {
+	return func(g *Group) {
+		g.failFast = true
+	}
+}
+
+// Group runs functions in goroutines, each within its own span of the tracer tname,
+// and aggregates their errors. Panics are recorded on the spans and returned as errors.
+type Group struct {
+	ctx      context.Context //nolint:containedctx
+	cancel   context.CancelCauseFunc
+	tname    string
+	linked   bool
+	failFast bool
+
+	waitGroup sync.WaitGroup
+	mutex     sync.Mutex
+	errs      []error
+}
+
+// NewGroup creates a Group and the context of its functions, derived from ctx.
+//
+// Usage:
+//
+//	group, ctx := tracew.NewGroup(ctx, "echo")
+//	for i := range count {
+//		group.Go("worker", func(ctx context.Context) error { return work(ctx, i) })
+//	}
+//	err := group.Wait()
+func NewGroup(ctx context.Context, tname string, options ...GroupOption) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	group := &Group{
+		ctx:    ctx,
+		cancel: cancel,
+		tname:  tname,
+	}
+
+	for _, option := range options {
+		option(group)
+	}
+
+	return group, ctx
+}
+
+// Go runs fn in a new goroutine within a new span named sname.
+// The span is a child of the span of the group context, or linked to it with WithLinkedSpans.
+func (g *Group) Go(sname string, fn func(ctx context.Context) error, options ...CallOption) {
+	// The options are copied, appending to them must not modify the array of the caller.
+	options = slices.Clone(options)
+
+	if g.linked {
+		options = append(options, WithStartOptions(
+			trace.WithNewRoot(),
+			trace.WithLinks(trace.LinkFromContext(g.ctx)),
+		))
+	}
+
+	options = append(options, WithPanicAsError())
+
+	g.waitGroup.Add(1)
+
+	go func() {
+		defer g.waitGroup.Done()
+
+		if err := Do(g.ctx, g.tname, sname, fn, options...); err != nil {
+			g.mutex.Lock()
+			g.errs = append(g.errs, err)
+			g.mutex.Unlock()
+
+			if g.failFast {
+				g.cancel(err)
+			}
+		}
+	}()
+}
+
+// Wait waits for all functions to return, cancels the group context
+// and returns their errors joined, or nil if all succeeded.
+func (g *Group) Wait() error {
+	g.waitGroup.Wait()
+	g.cancel(nil)
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return errors.Join(g.errs...)
+}
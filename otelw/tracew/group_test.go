@@ -0,0 +1,145 @@
+package tracew
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+//nolint:funlen
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		options []GroupOption
+		fails   bool
+	}
+
+	type want struct {
+		errs   int
+		linked bool
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "children",
+		},
+		{
+			name: "linked",
+			args: args{
+				options: []GroupOption{WithLinkedSpans()},
+			},
+			want: want{
+				linked: true,
+			},
+		},
+		{
+			name: "errors",
+			args: args{
+				fails: true,
+			},
+			want: want{
+				errs: 3,
+			},
+		},
+		{
+			name: "fail fast",
+			args: args{
+				options: []GroupOption{WithFailFast()},
+				fails:   true,
+			},
+			want: want{
+				errs: 3,
+			},
+		},
+	}
+
+	const workers = 3
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			provider, recorder := newRecorder()
+
+			ctx, parent := provider.Tracer("test/group").Start(context.Background(), "parent")
+
+			group, groupCtx := NewGroup(ctx, "test/group", test.args.options...)
+
+			for i := range workers {
+				group.Go(fmt.Sprintf("worker %d", i), func(context.Context) error {
+					switch {
+					case !test.args.fails:
+						return nil
+					case i == 0:
+						panic("test panic")
+					default:
+						return errTest
+					}
+				}, WithTracerProvider(provider))
+			}
+
+			err := group.Wait()
+			parent.End()
+
+			require.Error(t, groupCtx.Err())
+
+			if test.want.errs == 0 {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, errTest)
+				require.ErrorIs(t, err, ErrPanic)
+
+				joined, ok := err.(interface{ Unwrap() []error })
+				require.True(t, ok)
+				assert.Len(t, joined.Unwrap(), test.want.errs)
+			}
+
+			spans := recorder.Ended()
+			require.Len(t, spans, workers+1)
+
+			for _, span := range spans[:workers] {
+				if test.args.fails {
+					assert.Equal(t, codes.Error, span.Status().Code)
+				}
+
+				if test.want.linked {
+					assert.False(t, span.Parent().IsValid())
+					require.Len(t, span.Links(), 1)
+					assert.Equal(t, parent.SpanContext(), span.Links()[0].SpanContext)
+				} else {
+					assert.Equal(t, parent.SpanContext(), span.Parent())
+				}
+			}
+		})
+	}
+}
+
+func TestGroupOptions(t *testing.T) {
+	t.Parallel()
+
+	provider, recorder := newRecorder()
+
+	group, _ := NewGroup(context.Background(), "test/group", WithLinkedSpans())
+
+	// Options with spare capacity are shared by all goroutines.
+	options := make([]CallOption, 1, 8)
+	options[0] = WithTracerProvider(provider)
+
+	for i := range 8 {
+		group.Go(fmt.Sprintf("worker %d", i), func(context.Context) error { return nil }, options...)
+	}
+
+	require.NoError(t, group.Wait())
+	assert.Len(t, options, 1)
+	assert.Len(t, recorder.Ended(), 8)
+	assert.Nil(t, options[:8][1])
+}
@@ -0,0 +1,85 @@
+package tracew
+
+import (
+	"fmt"
+
+	"github.com/yolkhovyy/go-utilities/stringx"
+	"go.opentelemetry.io/contrib/propagators/aws/xray"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// IDGenerator selects the generator of trace and span IDs.
+type IDGenerator string
+
+const (
+	// IDGeneratorRandom generates random trace IDs.
+	IDGeneratorRandom IDGenerator = "random"
+	// IDGeneratorXRay generates AWS X-Ray compatible trace IDs, whose first 32 bits encode the epoch seconds.
+	// The X-Ray propagator is added to the propagators.
+	IDGeneratorXRay IDGenerator = "xray"
+)
+
+// String returns the string representation of the IDGenerator.
+func (g *IDGenerator) String() string {
+	return string(*g)
+}
+
+// ParseIDGenerator normalizes and validates an ID generator name.
+// Names are case-insensitive, surrounding spaces are ignored,
+// "x-ray" is accepted as an alias of "xray" and an empty name is accepted as "random".
+func ParseIDGenerator(name string) (IDGenerator, error) {
+	switch generator := IDGenerator(stringx.TrimSpaceToLower(name)); generator {
+	case IDGeneratorRandom, IDGeneratorXRay:
+		return generator, nil
+	case "x-ray":
+		return IDGeneratorXRay, nil
+	case "":
+		return IDGeneratorRandom, nil
+	default:
+		return "", fmt.Errorf("parse id generator: %w %s", ErrInvalidIDGenerator, name)
+	}
+}
+
+// UnmarshalText unmarshals a text value into an IDGenerator, normalizing and validating it.
+// It is also used by encoding/json for JSON string values.
+func (g *IDGenerator) UnmarshalText(text []byte) error {
+	generator, err := ParseIDGenerator(string(text))
+	if err != nil {
+		return fmt.Errorf("unmarshal: %w", err)
+	}
+
+	*g = generator
+
+	return nil
+}
+
+// UnmarshalYAML unmarshals a YAML value into an IDGenerator, validating the generator.
+// It returns an error if the generator is invalid.
+func (g *IDGenerator) UnmarshalYAML(unmarshal func(any) error) error {
+	var strGenerator string
+	if err := unmarshal(&strGenerator); err != nil {
+		return err
+	}
+
+	return g.UnmarshalText([]byte(strGenerator))
+}
+
+// options returns the tracer provider options of the ID generator,
+// invalid generators fall back to random, the SDK default.
+func (g IDGenerator) options() []sdktrace.TracerProviderOption {
+	if generator, _ := ParseIDGenerator(string(g)); generator == IDGeneratorXRay {
+		return []sdktrace.TracerProviderOption{sdktrace.WithIDGenerator(xray.NewIDGenerator())}
+	}
+
+	return nil
+}
+
+// propagators returns the additional propagators of the ID generator.
+func (g IDGenerator) propagators() []propagation.TextMapPropagator {
+	if generator, _ := ParseIDGenerator(string(g)); generator == IDGeneratorXRay {
+		return []propagation.TextMapPropagator{xray.Propagator{}}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package tracew
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+//nolint:funlen
+func TestIDGenerator(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		generator IDGenerator
+	}
+
+	type want struct {
+		epoch  bool
+		fields []string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "default",
+			args: args{},
+		},
+		{
+			name: "random",
+			args: args{generator: IDGeneratorRandom},
+		},
+		{
+			name: "xray",
+			args: args{generator: IDGeneratorXRay},
+			want: want{
+				epoch:  true,
+				fields: []string{"X-Amzn-Trace-Id"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			start := time.Now().Unix()
+
+			provider := sdktrace.NewTracerProvider(test.args.generator.options()...)
+
+			_, span := provider.Tracer("test").Start(context.Background(), "span")
+			span.End()
+
+			traceID := span.SpanContext().TraceID()
+			epoch := int64(binary.BigEndian.Uint32(traceID[:4]))
+
+			if test.want.epoch {
+				assert.GreaterOrEqual(t, epoch, start)
+				assert.LessOrEqual(t, epoch, time.Now().Unix())
+			}
+
+			propagator := propagation.NewCompositeTextMapPropagator(test.args.generator.propagators()...)
+			assert.ElementsMatch(t, test.want.fields, propagator.Fields())
+
+			require.NoError(t, provider.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestParseIDGenerator(t *testing.T) {
+	t.Parallel()
+
+	for name, want := range map[string]IDGenerator{
+		"":         IDGeneratorRandom,
+		" Random ": IDGeneratorRandom,
+		"XRay":     IDGeneratorXRay,
+		"x-ray":    IDGeneratorXRay,
+	} {
+		generator, err := ParseIDGenerator(name)
+		require.NoError(t, err)
+		assert.Equal(t, want, generator)
+	}
+
+	_, err := ParseIDGenerator("zipkin")
+	require.ErrorIs(t, err, ErrInvalidIDGenerator)
+}
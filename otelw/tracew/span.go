@@ -2,12 +2,24 @@ package tracew
 
 import (
 	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
+// flushTimeout bounds flushing the spans of a panicking span before re-panicking.
+const flushTimeout = 5 * time.Second
+
+// flusher is implemented by tracer providers that can flush their spans, e.g. sdktrace.TracerProvider.
+type flusher interface {
+	ForceFlush(ctx context.Context) error
+}
+
 // Span wraps trace.Span to provide additional functionality.
 type Span struct {
 	trace.Span
@@ -40,3 +52,112 @@ func (s *Span) End(err error, options ...trace.SpanEndOption) {
 
 	s.Span.End(options...)
 }
+
+// EndWithStatus finalizes the span with the given status code and description, without recording an error.
+// It is intended for failure classes that are not errors of the span, e.g. 4xx responses of a server,
+// which are recorded with codes.Unset. It accepts optional span end options.
+func (s *Span) EndWithStatus(code codes.Code, description string, options ...trace.SpanEndOption) {
+	s.SetStatus(code, description)
+	s.Span.End(options...)
+}
+
+// Event adds an event to the span, with attributes given as slog-style
+// alternating keys and values or slog.Attr:
+//
+//	span.Event("do echo", "sequence", sequence, slog.String("input", input))
+func (s *Span) Event(name string, args ...any) {
+	s.AddEvent(name, trace.WithAttributes(attributes(args...)...))
+}
+
+// Set sets an attribute of the span, typed after the value: integers, floats, booleans
+// and their slices keep their type, durations, times, errors and fmt.Stringers are
+// set as strings, other slices as string slices.
+func (s *Span) Set(key string, value any) {
+	s.SetAttributes(keyValue(key, value))
+}
+
+// Link links the span to the span of the context, e.g. of another trace,
+// with attributes given as slog-style alternating keys and values or slog.Attr.
+// Contexts without a valid span are ignored.
+func (s *Span) Link(ctx context.Context, args ...any) {
+	link := trace.LinkFromContext(ctx, attributes(args...)...)
+	if !link.SpanContext.IsValid() {
+		return
+	}
+
+	s.AddLink(link)
+}
+
+// EndRecover finalizes the span like End, recovering a panic of the function that deferred it.
+// A panic is recorded as an exception event with the panic value and stack,
+// the span status is set to error, the span is ended and flushed, and the panic is propagated.
+// The error pointed to by err is recorded on the span, and set to the panic error if the function panics.
+// EndRecover must be deferred directly to recover panics:
+//
+//	defer span.EndRecover(&err)
+func (s *Span) EndRecover(err *error, options ...trace.SpanEndOption) {
+	if recovered := recover(); recovered != nil {
+		s.endPanic(recovered, err, options...)
+		s.flush()
+
+		panic(recovered)
+	}
+
+	s.End(deref(err), options...)
+}
+
+// EndRecoverAsError finalizes the span like EndRecover, but converts a panic
+// into the error pointed to by err instead of propagating it.
+// It is intended for goroutine workers, where an unrecovered panic crashes the process.
+// EndRecoverAsError must be deferred directly to recover panics:
+//
+//	defer span.EndRecoverAsError(&err)
+func (s *Span) EndRecoverAsError(err *error, options ...trace.SpanEndOption) {
+	if recovered := recover(); recovered != nil {
+		s.endPanic(recovered, err, options...)
+
+		return
+	}
+
+	s.End(deref(err), options...)
+}
+
+// endPanic records the recovered panic value and stack as an exception event,
+// marks the span status as an error and ends the span. The panic error is stored in err if not nil.
+func (s *Span) endPanic(recovered any, err *error, options ...trace.SpanEndOption) {
+	panicErr := fmt.Errorf("%w: %v", ErrPanic, recovered)
+
+	s.AddEvent(semconv.ExceptionEventName, trace.WithAttributes(
+		semconv.ExceptionType(fmt.Sprintf("%T", recovered)),
+		semconv.ExceptionMessage(panicErr.Error()),
+		semconv.ExceptionStacktrace(string(debug.Stack())),
+	))
+	s.SetStatus(codes.Error, panicErr.Error())
+	s.Span.End(options...)
+
+	if err != nil {
+		*err = panicErr
+	}
+}
+
+// flush flushes the spans of the span's tracer provider, so that the panic is exported before the process exits.
+func (s *Span) flush() {
+	provider, ok := s.TracerProvider().(flusher)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+
+	_ = provider.ForceFlush(ctx)
+}
+
+// deref returns the error pointed to by err, or nil if err is nil.
+func deref(err *error) error {
+	if err == nil {
+		return nil
+	}
+
+	return *err
+}
@@ -0,0 +1,169 @@
+package tracew
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+//nolint:funlen
+func TestSpanEndRecover(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		asError bool
+		panics  bool
+		err     error
+	}
+
+	type want struct {
+		repanics bool
+		err      error
+		status   codes.Code
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "no panic",
+			want: want{
+				status: codes.Ok,
+			},
+		},
+		{
+			name: "error",
+			args: args{
+				err: errTest,
+			},
+			want: want{
+				err:    errTest,
+				status: codes.Error,
+			},
+		},
+		{
+			name: "panic",
+			args: args{
+				panics: true,
+			},
+			want: want{
+				repanics: true,
+				err:      ErrPanic,
+				status:   codes.Error,
+			},
+		},
+		{
+			name: "panic as error",
+			args: args{
+				asError: true,
+				panics:  true,
+			},
+			want: want{
+				err:    ErrPanic,
+				status: codes.Error,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			provider, recorder := newRecorder()
+
+			var err error
+
+			work := func() {
+				_, tspan := provider.Tracer("test/span").Start(context.Background(), "work")
+				span := Span{Span: tspan}
+
+				if test.args.asError {
+					defer span.EndRecoverAsError(&err)
+				} else {
+					defer span.EndRecover(&err)
+				}
+
+				if test.args.panics {
+					panic("test panic")
+				}
+
+				err = test.args.err
+			}
+
+			if test.want.repanics {
+				assert.PanicsWithValue(t, "test panic", work)
+			} else {
+				assert.NotPanics(t, work)
+			}
+
+			if test.want.err != nil {
+				require.ErrorIs(t, err, test.want.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, test.want.status, spans[0].Status().Code)
+
+			if !test.args.panics {
+				return
+			}
+
+			events := spans[0].Events()
+			require.Len(t, events, 1)
+			assert.Equal(t, semconv.ExceptionEventName, events[0].Name)
+			assert.Contains(t, events[0].Attributes, semconv.ExceptionType("string"))
+			assert.Contains(t, events[0].Attributes, semconv.ExceptionMessage("panic: test panic"))
+		})
+	}
+}
+
+func TestSpanHelpers(t *testing.T) {
+	t.Parallel()
+
+	provider, recorder := newRecorder()
+	tracer := provider.Tracer("test/span/helpers")
+
+	linkedCtx, linked := tracer.Start(context.Background(), "linked")
+	linked.End()
+
+	_, tspan := tracer.Start(context.Background(), "helpers")
+	span := Span{Span: tspan}
+	span.Event("do echo", "sequence", 1, slog.String("input", "hello"))
+	span.Set("elapsed", 1500*time.Millisecond)
+	span.Set("sequences", []int{1, 2})
+	span.Link(linkedCtx, "reason", "follows")
+	span.Link(context.Background())
+	span.EndWithStatus(codes.Unset, "client error")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	helpers := spans[1]
+	assert.Equal(t, codes.Unset, helpers.Status().Code)
+	assert.Empty(t, helpers.Status().Description)
+
+	require.Len(t, helpers.Events(), 1)
+	assert.Equal(t, "do echo", helpers.Events()[0].Name)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Int64("sequence", 1),
+		attribute.String("input", "hello"),
+	}, helpers.Events()[0].Attributes)
+
+	assert.Contains(t, helpers.Attributes(), attribute.String("elapsed", "1.5s"))
+	assert.Contains(t, helpers.Attributes(), attribute.IntSlice("sequences", []int{1, 2}))
+
+	require.Len(t, helpers.Links(), 1)
+	assert.Equal(t, spans[0].SpanContext().SpanID(), helpers.Links()[0].SpanContext.SpanID())
+	assert.Equal(t, []attribute.KeyValue{attribute.String("reason", "follows")}, helpers.Links()[0].Attributes)
+}
@@ -8,9 +8,11 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Tracer is a wrapper around the OpenTelemetry TracerProvider and SpanExporter.
@@ -18,6 +20,8 @@ import (
 type Tracer struct {
 	provider *sdktrace.TracerProvider
 	exporter sdktrace.SpanExporter
+	parent   trace.SpanContext
+	baggage  baggage.Baggage
 }
 
 // Configure sets up the Tracer with the given configuration, attributes, and optional writers.
@@ -28,6 +32,10 @@ func Configure(
 	attrs []attribute.KeyValue,
 	writers ...io.Writer,
 ) (*Tracer, error) {
+	if err := config.validate(len(writers) == 0); err != nil {
+		return nil, fmt.Errorf("tracew configure: %w", err)
+	}
+
 	exporter, err := exporter(ctx, config, writers...)
 	if err != nil {
 		return nil, fmt.Errorf("tracew configure: %w", err)
@@ -54,23 +62,42 @@ func Configure(
 		return nil, fmt.Errorf("tracew configure resource merge: %w", err)
 	}
 
-	provider := sdktrace.NewTracerProvider(
+	options := []sdktrace.TracerProviderOption{
 		sdktrace.WithResource(res),
 		sdktrace.WithSampler(sdktrace.AlwaysSample()),
-		sdktrace.WithSpanProcessor(sdktrace.NewBatchSpanProcessor(exporter)))
+	}
+
+	options = append(options, config.IDGenerator.options()...)
+
+	// Baggage attributes are set on span start, before the span is exported.
+	if len(config.BaggageKeys) > 0 {
+		options = append(options, sdktrace.WithSpanProcessor(NewBaggageSpanProcessor(config.BaggageKeys...)))
+	}
 
-	propagator := propagation.NewCompositeTextMapPropagator(
+	options = append(options, sdktrace.WithSpanProcessor(sdktrace.NewBatchSpanProcessor(exporter)))
+
+	provider := sdktrace.NewTracerProvider(options...)
+
+	propagator := propagation.NewCompositeTextMapPropagator(append([]propagation.TextMapPropagator{
 		propagation.TraceContext{},
 		propagation.Baggage{},
-	)
+	}, config.IDGenerator.propagators()...)...)
 
 	otel.SetTextMapPropagator(propagator)
 	otel.SetTracerProvider(provider)
 
-	return &Tracer{
+	tracer := &Tracer{
 		provider: provider,
 		exporter: exporter,
-	}, nil
+	}
+
+	if config.EnvParent {
+		parent := ExtractEnv(context.Background())
+		tracer.parent = trace.SpanContextFromContext(parent)
+		tracer.baggage = baggage.FromContext(parent)
+	}
+
+	return tracer, nil
 }
 
 // Shutdown gracefully shuts down the Tracer, ensuring all spans are flushed and resources are released.
@@ -0,0 +1,11 @@
+// Package validation provides path-qualified configuration errors,
+// used by the Validate methods of the go-otelw configuration types
+// to report all configuration problems at once.
+//
+// Usage:
+//
+//	if err := config.Validate(); err != nil {
+//		fmt.Fprintf(os.Stderr, "config validate:\n%v", err)
+//		return osx.ExitConfigError
+//	}
+package validation
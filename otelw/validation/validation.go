@@ -0,0 +1,77 @@
+package validation
+
+import "errors"
+
+// Error is a configuration problem qualified by the path of the configuration field,
+// e.g. Tracer.OTLP.Endpoint.
+type Error struct {
+	// Path of the configuration field.
+	Path string
+
+	// Err describes the problem.
+	Err error
+}
+
+// Error returns the path-qualified error message.
+func (e *Error) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+
+	return e.Path + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Field returns an Error for the configuration field at the given path, or nil if err is nil.
+func Field(path string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Error{Path: path, Err: err}
+}
+
+// Join joins the given errors, qualifying their paths with the prefix.
+// Joined errors are flattened, so that the result lists one problem per line.
+// Join returns nil if all errors are nil.
+func Join(prefix string, errs ...error) error {
+	flat := make([]error, 0, len(errs))
+
+	for _, err := range errs {
+		flat = flatten(flat, prefix, err)
+	}
+
+	return errors.Join(flat...)
+}
+
+func flatten(flat []error, prefix string, err error) []error {
+	switch typed := err.(type) { //nolint:errorlint
+	case nil:
+		return flat
+	case *Error:
+		return append(flat, &Error{Path: join(prefix, typed.Path), Err: typed.Err})
+	case interface{ Unwrap() []error }:
+		for _, e := range typed.Unwrap() {
+			flat = flatten(flat, prefix, e)
+		}
+
+		return flat
+	default:
+		return append(flat, &Error{Path: prefix, Err: err})
+	}
+}
+
+func join(prefix, path string) string {
+	switch {
+	case prefix == "":
+		return path
+	case path == "":
+		return prefix
+	default:
+		return prefix + "." + path
+	}
+}